@@ -19,11 +19,12 @@ type AutoModQueueEvent struct {
 		Content struct {
 			Text      string `json:"text"`
 			Fragments []struct {
-				Text    string `json:"text"`
+				Text     string `json:"text"`
+				Emoticon *struct {
+					ID string `json:"emoticonID"`
+				} `json:"emoticon"`
 				Automod struct {
-					Topics struct {
-						Swearing int `json:"swearing"`
-					} `json:"topics"`
+					Topics AutoModFragmentTopics `json:"topics"`
 				} `json:"automod"`
 			} `json:"fragments"`
 		} `json:"content"`
@@ -43,9 +44,45 @@ type AutoModQueueEvent struct {
 	ReasonCode    string `json:"reason_code"`
 	ResolverID    string `json:"resolver_id"`
 	ResolverLogin string `json:"resolver_login"`
+
+	RawEvent
+}
+
+// AutoModFragmentTopics describes the topics AutoMod flagged a message fragment for
+type AutoModFragmentTopics struct {
+	// Swearing is the same as Levels["swearing"]
+	Swearing int
+
+	// Levels maps each topic, e.g. "swearing" or "aggressive", to the level it was flagged at
+	Levels map[string]int
+}
+
+// UnmarshalJSON decodes the topics object into Levels, since Twitch adds new topics without notice
+func (t *AutoModFragmentTopics) UnmarshalJSON(bytes []byte) error {
+	var levels map[string]int
+	if err := json.Unmarshal(bytes, &levels); err != nil {
+		return err
+	}
+
+	t.Levels = levels
+	t.Swearing = levels["swearing"]
+
+	return nil
+}
+
+// Flagged returns true if AutoMod flagged the fragment for any topic
+func (t AutoModFragmentTopics) Flagged() bool {
+	for _, level := range t.Levels {
+		if level > 0 {
+			return true
+		}
+	}
+
+	return false
 }
 
 type outerAutoModQueueEvent struct {
+	Type string            `json:"type"`
 	Data AutoModQueueEvent `json:"data"`
 }
 
@@ -56,6 +93,8 @@ func parseAutoModQueueEvent(bytes []byte) (*AutoModQueueEvent, error) {
 		return nil, err
 	}
 
+	data.Data.RawEvent = newRawEvent(bytes, data)
+
 	return &data.Data, nil
 }
 
@@ -10,7 +10,10 @@ import (
 	"time"
 )
 
-const bitsEventTopicPrefix = "channel-bits-events-v1."
+const (
+	bitsEventTopicPrefix   = "channel-bits-events-v1."
+	bitsEventV2TopicPrefix = "channel-bits-events-v2."
+)
 
 // BitsEvent describes an incoming "Bit" action coming from Twitch's PubSub servers
 type BitsEvent struct {
@@ -38,14 +41,31 @@ type BitsEvent struct {
 
 	Context string `json:"context"`
 
-	BadgeEntitlement struct {
-		NewVersion      int `json:"new_version"`
-		PreviousVersion int `json:"previous_version"`
-	} `json:"badge_entitlement"`
+	// IsAnonymous is true if the bits were sent anonymously
+	// Only set for events coming from the v2 topic
+	IsAnonymous bool `json:"is_anonymous"`
+
+	// BadgeEntitlement is nil unless the user unlocked a new bits badge with this cheer
+	BadgeEntitlement *BitsBadgeEntitlement `json:"badge_entitlement"`
+
+	RawEvent
+}
+
+// BitsBadgeEntitlement describes the bits badge change caused by a cheer
+type BitsBadgeEntitlement struct {
+	NewVersion      int `json:"new_version"`
+	PreviousVersion int `json:"previous_version"`
 }
 
 type outerBitsEvent struct {
 	Data BitsEvent `json:"data"`
+
+	Version     string `json:"version"`
+	MessageType string `json:"message_type"`
+	MessageID   string `json:"message_id"`
+
+	// IsAnonymous is only sent on the v2 topic
+	IsAnonymous bool `json:"is_anonymous"`
 }
 
 func parseBitsEvent(bytes []byte) (*BitsEvent, error) {
@@ -55,6 +75,12 @@ func parseBitsEvent(bytes []byte) (*BitsEvent, error) {
 		return nil, err
 	}
 
+	if data.IsAnonymous {
+		data.Data.IsAnonymous = true
+	}
+
+	data.Data.RawEvent = newRawEvent(bytes, data)
+
 	return &data.Data, nil
 }
 
@@ -68,7 +94,7 @@ func parseChannelIDFromBitsTopic(topic string) (string, error) {
 }
 
 func isBitsEventTopic(topic string) bool {
-	return strings.HasPrefix(topic, bitsEventTopicPrefix)
+	return strings.HasPrefix(topic, bitsEventTopicPrefix) || strings.HasPrefix(topic, bitsEventV2TopicPrefix)
 }
 
 // BitsEventTopic returns a properly formatted bits event topic string with the given channel ID argument
@@ -76,3 +102,10 @@ func BitsEventTopic(channelID string) string {
 	const f = `channel-bits-events-v1.%s`
 	return fmt.Sprintf(f, channelID)
 }
+
+// BitsEventV2Topic returns a properly formatted v2 bits event topic string with the given channel ID argument
+// Events from this topic are delivered through the same OnBitsEvent callback as the v1 topic
+func BitsEventV2Topic(channelID string) string {
+	const f = `channel-bits-events-v2.%s`
+	return fmt.Sprintf(f, channelID)
+}
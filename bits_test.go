@@ -118,6 +118,61 @@ func TestParseBitsEvent(t *testing.T) {
 			expectedErr:      nil,
 			expectedOuterErr: nil,
 		},
+		{
+			label:      "v2 badge entitlement",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-bits-events-v2.46024993","message":"{\"data\":{\"user_name\":\"jwp\",\"channel_name\":\"bontakun\",\"user_id\":\"95546976\",\"channel_id\":\"46024993\",\"time\":\"2017-02-09T13:23:58.168Z\",\"chat_message\":\"cheer10000 New badge hype!\",\"bits_used\":10000,\"total_bits_used\":25000,\"is_anonymous\":false,\"context\":\"cheer\",\"badge_entitlement\":{\"new_version\":25000,\"previous_version\":10000}},\"version\":\"1.0\",\"message_type\":\"bits_event\",\"message_id\":\"8145728a4-35f0-4cf7-9dc0-f2ef24de1eb6\",\"is_anonymous\":false}"}}`,
+			isValidMsg: true,
+			expected: &BitsEvent{
+				UserName: "jwp",
+				UserID:   "95546976",
+
+				ChannelName: "bontakun",
+				ChannelID:   "46024993",
+
+				Time: time.Date(2017, time.February, 9, 13, 23, 58, 168000000, time.UTC),
+
+				ChatMessage: "cheer10000 New badge hype!",
+
+				BitsUsed: 10000,
+
+				TotalBitsUsed: 25000,
+
+				Context: "cheer",
+
+				BadgeEntitlement: &BitsBadgeEntitlement{
+					NewVersion:      25000,
+					PreviousVersion: 10000,
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "v2 anonymous",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-bits-events-v2.46024993","message":"{\"data\":{\"user_name\":\"ananonymouscheerer\",\"channel_name\":\"bontakun\",\"user_id\":\"407665396\",\"channel_id\":\"46024993\",\"time\":\"2017-02-09T13:23:58.168Z\",\"chat_message\":\"Anon100\",\"bits_used\":100,\"total_bits_used\":0,\"context\":\"cheer\",\"badge_entitlement\":null},\"version\":\"1.0\",\"message_type\":\"bits_event\",\"message_id\":\"bc6ad0a7-4b8d-5ee4-9d10-0c8a9d2bd3c9\",\"is_anonymous\":true}"}}`,
+			isValidMsg: true,
+			expected: &BitsEvent{
+				UserName: "ananonymouscheerer",
+				UserID:   "407665396",
+
+				ChannelName: "bontakun",
+				ChannelID:   "46024993",
+
+				Time: time.Date(2017, time.February, 9, 13, 23, 58, 168000000, time.UTC),
+
+				ChatMessage: "Anon100",
+
+				BitsUsed: 100,
+
+				TotalBitsUsed: 0,
+
+				Context: "cheer",
+
+				IsAnonymous: true,
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
 
 		{
 			label:            "Invalid message JSON",
@@ -146,7 +201,7 @@ func TestParseBitsEvent(t *testing.T) {
 					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
 				}
 
-				c.Assert(actual, qt.DeepEquals, testCase.expected)
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
 			}
 		})
 	}
@@ -187,6 +242,36 @@ func TestCreateBitsTopic(t *testing.T) {
 	}
 }
 
+func TestCreateBitsV2Topic(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label          string
+		inputChannelID string
+		expected       string
+	}
+
+	testCases := []testCase{
+		{
+			label:          "Standard",
+			inputChannelID: "456",
+			expected:       "channel-bits-events-v2.456",
+		},
+		{
+			label:          "Bad",
+			inputChannelID: "",
+			expected:       "channel-bits-events-v2.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual := BitsEventV2Topic(testCase.inputChannelID)
+			c.Assert(actual, qt.Equals, testCase.expected)
+		})
+	}
+}
+
 func TestParseBitsTopicChannelID(t *testing.T) {
 	c := qt.New(t)
 
@@ -204,6 +289,12 @@ func TestParseBitsTopicChannelID(t *testing.T) {
 			expectedChannelID: "456",
 			expectedErr:       nil,
 		},
+		{
+			label:             "Standard v2",
+			inputTopic:        "channel-bits-events-v2.456",
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
 		{
 			label:             "Malformed but successful",
 			inputTopic:        "channel-bits-events-v1.",
@@ -0,0 +1,69 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch bits badge unlock events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const bitsBadgeUnlockEventTopicPrefix = "channel-bits-badge-unlocks."
+
+// BitsBadgeUnlockEvent describes a user unlocking a new bits badge tier, coming from Twitch's PubSub servers
+type BitsBadgeUnlockEvent struct {
+	// UserID is the ID of the user who unlocked the badge
+	UserID string `json:"user_id"`
+	// UserName is the login name of the user who unlocked the badge
+	UserName string `json:"user_name"`
+
+	// ChannelID is the ID of the channel the badge was unlocked in
+	ChannelID string `json:"channel_id"`
+	// ChannelName is the login name of the channel the badge was unlocked in
+	ChannelName string `json:"channel_name"`
+
+	// BadgeTier is the bits badge tier that was unlocked (e.g. 1000, 5000)
+	BadgeTier int `json:"badge_tier"`
+
+	// ChatMessage is the message the user chose to share with the badge unlock
+	// Can be empty if the user did not share a message
+	ChatMessage string `json:"chat_message"`
+
+	// Time the badge was unlocked
+	Time time.Time `json:"time"`
+
+	RawEvent
+}
+
+func parseBitsBadgeUnlockEvent(bytes []byte) (*BitsBadgeUnlockEvent, error) {
+	data := &BitsBadgeUnlockEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+func parseChannelIDFromBitsBadgeUnlockTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from bits badge unlock topic")
+	}
+
+	return parts[1], nil
+}
+
+func isBitsBadgeUnlockEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, bitsBadgeUnlockEventTopicPrefix)
+}
+
+// BitsBadgeUnlockEventTopic returns a properly formatted bits badge unlock event topic string with the given channel ID argument
+func BitsBadgeUnlockEventTopic(channelID string) string {
+	const f = `channel-bits-badge-unlocks.%s`
+	return fmt.Sprintf(f, channelID)
+}
@@ -0,0 +1,112 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseBitsBadgeUnlockEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *BitsBadgeUnlockEvent
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Badge unlock with message",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-bits-badge-unlocks.232889822","message":"{\"user_id\":\"232889822\",\"user_name\":\"willowolf\",\"channel_id\":\"232889822\",\"channel_name\":\"willowolf\",\"badge_tier\":1000,\"chat_message\":\"this should be received by the public pubsub listener\",\"time\":\"2020-12-06T00:01:43.71253159Z\"}"}}`,
+			isValidMsg: true,
+			expected: &BitsBadgeUnlockEvent{
+				UserID:   "232889822",
+				UserName: "willowolf",
+
+				ChannelID:   "232889822",
+				ChannelName: "willowolf",
+
+				BadgeTier: 1000,
+
+				ChatMessage: "this should be received by the public pubsub listener",
+
+				Time: time.Date(2020, time.December, 6, 0, 1, 43, 712531590, time.UTC),
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-bits-badge-unlocks.232889822","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isBitsBadgeUnlockEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseBitsBadgeUnlockEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseBitsBadgeUnlockTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label             string
+		inputTopic        string
+		expectedChannelID string
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			label:             "Standard",
+			inputTopic:        BitsBadgeUnlockEventTopic("456"),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Malformed",
+			inputTopic:        "channel-bits-badge-unlocks",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from bits badge unlock topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actualChannelID, err := parseChannelIDFromBitsBadgeUnlockTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(actualChannelID, qt.Equals, testCase.expectedChannelID)
+		})
+	}
+}
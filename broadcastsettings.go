@@ -0,0 +1,81 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch broadcast settings (title & game) events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const broadcastSettingsEventTopicPrefix = "broadcast-settings-update."
+
+// BroadcastSettingsUpdate describes a change to a channel's title or game, coming from Twitch's PubSub servers
+type BroadcastSettingsUpdate struct {
+	// Type is the kind of update, e.g. "broadcast_settings_update"
+	Type string `json:"type"`
+
+	// ChannelID is the ID of the channel whose settings changed
+	ChannelID string `json:"channel_id"`
+	// Channel is the login name of the channel whose settings changed
+	Channel string `json:"channel"`
+
+	// OldStatus is the previous stream title
+	OldStatus string `json:"old_status"`
+	// Status is the new stream title
+	Status string `json:"status"`
+
+	// OldGame is the name of the previous game
+	OldGame string `json:"old_game"`
+	// Game is the name of the new game
+	Game string `json:"game"`
+
+	// OldGameID is the ID of the previous game
+	OldGameID int `json:"old_game_id"`
+	// GameID is the ID of the new game
+	GameID int `json:"game_id"`
+
+	RawEvent
+}
+
+// GameChanged returns true if this update changed the channel's game
+func (e *BroadcastSettingsUpdate) GameChanged() bool {
+	return e.OldGameID != e.GameID
+}
+
+// StatusChanged returns true if this update changed the channel's title
+func (e *BroadcastSettingsUpdate) StatusChanged() bool {
+	return e.OldStatus != e.Status
+}
+
+func parseBroadcastSettingsUpdate(bytes []byte) (*BroadcastSettingsUpdate, error) {
+	data := &BroadcastSettingsUpdate{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+func parseChannelIDFromBroadcastSettingsTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from broadcast settings topic")
+	}
+
+	return parts[1], nil
+}
+
+func isBroadcastSettingsEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, broadcastSettingsEventTopicPrefix)
+}
+
+// BroadcastSettingsEventTopic returns a properly formatted broadcast settings event topic string with the given channel ID argument
+func BroadcastSettingsEventTopic(channelID string) string {
+	const f = `broadcast-settings-update.%s`
+	return fmt.Sprintf(f, channelID)
+}
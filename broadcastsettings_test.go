@@ -0,0 +1,84 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseBroadcastSettingsUpdate(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *BroadcastSettingsUpdate
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Game change",
+			input:      `{"type":"MESSAGE","data":{"topic":"broadcast-settings-update.11148817","message":"{\"channel_id\":\"11148817\",\"type\":\"broadcast_settings_update\",\"channel\":\"pajlada\",\"old_status\":\"coding\",\"status\":\"coding\",\"old_game\":\"Software and Game Development\",\"game\":\"Just Chatting\",\"old_game_id\":1469308723,\"game_id\":509658}"}}`,
+			isValidMsg: true,
+			expected: &BroadcastSettingsUpdate{
+				Type:      "broadcast_settings_update",
+				ChannelID: "11148817",
+				Channel:   "pajlada",
+				OldStatus: "coding",
+				Status:    "coding",
+				OldGame:   "Software and Game Development",
+				Game:      "Just Chatting",
+				OldGameID: 1469308723,
+				GameID:    509658,
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"broadcast-settings-update.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isBroadcastSettingsEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseBroadcastSettingsUpdate(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual.GameChanged(), qt.IsTrue)
+					c.Assert(actual.StatusChanged(), qt.IsFalse)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseBroadcastSettingsTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromBroadcastSettingsTopic(BroadcastSettingsEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromBroadcastSettingsTopic("broadcast-settings-update")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from broadcast settings topic")
+}
@@ -0,0 +1,105 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch charity campaign donation events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const charityDonationEventTopicPrefix = "charity-campaign-donation-events-v1."
+
+// CharityAmount describes an amount of money in the smallest unit of its currency
+// For example, 5.50 USD is sent as Value 550 with DecimalPlaces 2
+type CharityAmount struct {
+	Value         int    `json:"value"`
+	DecimalPlaces int    `json:"decimal_places"`
+	Currency      string `json:"currency"`
+}
+
+// Float64 returns the amount in whole units of its currency
+func (a CharityAmount) Float64() float64 {
+	return float64(a.Value) / math.Pow10(a.DecimalPlaces)
+}
+
+// String returns the amount formatted with its decimal places and currency, e.g. "5.50 USD"
+// The amount is formatted from the integer value, so it is exact for any amount
+func (a CharityAmount) String() string {
+	digits := strconv.Itoa(a.Value)
+	if a.DecimalPlaces <= 0 {
+		return digits + " " + a.Currency
+	}
+
+	sign := ""
+	if a.Value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	// Pad with zeros so there is at least one digit before the dot, e.g. 5 with 2 decimal places is "0.05"
+	if len(digits) <= a.DecimalPlaces {
+		digits = strings.Repeat("0", a.DecimalPlaces-len(digits)+1) + digits
+	}
+
+	split := len(digits) - a.DecimalPlaces
+	return sign + digits[:split] + "." + digits[split:] + " " + a.Currency
+}
+
+// CharityDonationEvent describes a donation to a channel's charity campaign, coming from Twitch's PubSub servers
+type CharityDonationEvent struct {
+	// Type is the kind of event, e.g. "charity_campaign_donation"
+	Type string `json:"-"`
+
+	ID string `json:"id"`
+
+	CampaignID  string `json:"campaign_id"`
+	CharityName string `json:"charity_name"`
+
+	DonorID          string `json:"donor_id"`
+	DonorLogin       string `json:"donor_login"`
+	DonorDisplayName string `json:"donor_display_name"`
+
+	Amount CharityAmount `json:"amount"`
+
+	RawEvent
+}
+
+type outerCharityDonationEvent struct {
+	Type string               `json:"type"`
+	Data CharityDonationEvent `json:"data"`
+}
+
+func parseCharityDonationEvent(bytes []byte) (*CharityDonationEvent, error) {
+	data := &outerCharityDonationEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Data.Type = data.Type
+	data.Data.RawEvent = newRawEvent(bytes, data)
+
+	return &data.Data, nil
+}
+
+func parseChannelIDFromCharityDonationTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from charity donation topic")
+	}
+
+	return parts[1], nil
+}
+
+func isCharityDonationEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, charityDonationEventTopicPrefix)
+}
+
+// CharityDonationEventTopic returns a properly formatted charity donation event topic string with the given channel ID argument
+func CharityDonationEventTopic(channelID string) string {
+	const f = `charity-campaign-donation-events-v1.%s`
+	return fmt.Sprintf(f, channelID)
+}
@@ -0,0 +1,97 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseCharityDonationEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *CharityDonationEvent
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Donation",
+			input:      `{"type":"MESSAGE","data":{"topic":"charity-campaign-donation-events-v1.11148817","message":"{\"type\":\"charity_campaign_donation\",\"data\":{\"id\":\"a1b2c3d4-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"campaign_id\":\"e5f6a7b8-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"charity_name\":\"Example Charity\",\"donor_id\":\"133077169\",\"donor_login\":\"slurps\",\"donor_display_name\":\"slurps\",\"amount\":{\"value\":550,\"decimal_places\":2,\"currency\":\"USD\"}}}"}}`,
+			isValidMsg: true,
+			expected: &CharityDonationEvent{
+				Type:             "charity_campaign_donation",
+				ID:               "a1b2c3d4-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+				CampaignID:       "e5f6a7b8-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+				CharityName:      "Example Charity",
+				DonorID:          "133077169",
+				DonorLogin:       "slurps",
+				DonorDisplayName: "slurps",
+				Amount: CharityAmount{
+					Value:         550,
+					DecimalPlaces: 2,
+					Currency:      "USD",
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"charity-campaign-donation-events-v1.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isCharityDonationEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseCharityDonationEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCharityAmount(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(CharityAmount{Value: 550, DecimalPlaces: 2, Currency: "USD"}.Float64(), qt.Equals, 5.5)
+	c.Assert(CharityAmount{Value: 550, DecimalPlaces: 2, Currency: "USD"}.String(), qt.Equals, "5.50 USD")
+	c.Assert(CharityAmount{Value: 1000, DecimalPlaces: 0, Currency: "JPY"}.String(), qt.Equals, "1000 JPY")
+	c.Assert(CharityAmount{Value: 5, DecimalPlaces: 2, Currency: "USD"}.String(), qt.Equals, "0.05 USD")
+	c.Assert(CharityAmount{Value: 50, DecimalPlaces: 2, Currency: "USD"}.String(), qt.Equals, "0.50 USD")
+	c.Assert(CharityAmount{Value: -550, DecimalPlaces: 2, Currency: "USD"}.String(), qt.Equals, "-5.50 USD")
+	c.Assert(CharityAmount{Value: 9007199254740993, DecimalPlaces: 2, Currency: "USD"}.String(), qt.Equals, "90071992547409.93 USD")
+}
+
+func TestParseCharityDonationTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromCharityDonationTopic(CharityDonationEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromCharityDonationTopic("charity-campaign-donation-events-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from charity donation topic")
+}
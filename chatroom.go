@@ -0,0 +1,148 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch chat room settings events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const chatRoomEventTopicPrefix = "stream-chat-room-v1."
+
+// ChatRoomUpdateTypeUpdatedRoom is the ChatRoomUpdate.Type of updates that replace the full state of the chat room
+const ChatRoomUpdateTypeUpdatedRoom = "updated_room"
+
+// ChatRoomModes describes the chat modes currently active in a chat room
+type ChatRoomModes struct {
+	// FollowersOnlyDurationMinutes is nil if followers-only mode is disabled
+	FollowersOnlyDurationMinutes *int `json:"followers_only_duration_minutes"`
+
+	EmoteOnlyModeEnabled       bool `json:"emote_only_mode_enabled"`
+	R9KModeEnabled             bool `json:"r9k_mode_enabled"`
+	SubscribersOnlyModeEnabled bool `json:"subscribers_only_mode_enabled"`
+	VerifiedOnlyModeEnabled    bool `json:"verified_only_mode_enabled"`
+
+	// SlowModeDurationSeconds is nil if slow mode is disabled
+	SlowModeDurationSeconds *int `json:"slow_mode_duration_seconds"`
+	// SlowModeSetAt is nil if slow mode is disabled
+	SlowModeSetAt *time.Time `json:"slow_mode_set_at"`
+}
+
+// ChatRoomState describes the full settings of a chat room
+type ChatRoomState struct {
+	ChannelID string        `json:"channel_id"`
+	Name      string        `json:"name"`
+	Modes     ChatRoomModes `json:"modes"`
+	// Rules are the chat rules shown to users before they chat for the first time
+	Rules []string `json:"rules"`
+}
+
+// clone returns a deep copy of the state, so it shares no slices or pointers with s
+func (s ChatRoomState) clone() ChatRoomState {
+	if s.Rules != nil {
+		s.Rules = append([]string{}, s.Rules...)
+	}
+	if s.Modes.FollowersOnlyDurationMinutes != nil {
+		v := *s.Modes.FollowersOnlyDurationMinutes
+		s.Modes.FollowersOnlyDurationMinutes = &v
+	}
+	if s.Modes.SlowModeDurationSeconds != nil {
+		v := *s.Modes.SlowModeDurationSeconds
+		s.Modes.SlowModeDurationSeconds = &v
+	}
+	if s.Modes.SlowModeSetAt != nil {
+		v := *s.Modes.SlowModeSetAt
+		s.Modes.SlowModeSetAt = &v
+	}
+
+	return s
+}
+
+// ChatRoomUpdate describes a change to a chat room's settings, coming from Twitch's PubSub servers
+type ChatRoomUpdate struct {
+	// Type is the kind of update, e.g. ChatRoomUpdateTypeUpdatedRoom
+	Type string
+
+	// Room is the full state of the chat room after the update
+	Room ChatRoomState
+
+	RawEvent
+}
+
+type outerChatRoomUpdate struct {
+	Type string `json:"type"`
+	Data struct {
+		Room *ChatRoomState `json:"room"`
+	} `json:"data"`
+}
+
+// parseChatRoomUpdate parses any message sent on the chat room topic
+// Returns nil without an error for messages that carry no room data, since those are not handled
+func parseChatRoomUpdate(bytes []byte) (*ChatRoomUpdate, error) {
+	data := &outerChatRoomUpdate{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Data.Room == nil {
+		// This topic also carries messages without room data, e.g. "chat_rich_embed", which we don't handle
+		return nil, nil
+	}
+
+	return &ChatRoomUpdate{
+		Type:     data.Type,
+		Room:     *data.Data.Room,
+		RawEvent: newRawEvent(bytes, data),
+	}, nil
+}
+
+func parseChannelIDFromChatRoomTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from chat room topic")
+	}
+
+	return parts[1], nil
+}
+
+func isChatRoomEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, chatRoomEventTopicPrefix)
+}
+
+// ChatRoomEventTopic returns a properly formatted chat room event topic string with the given channel ID argument
+func ChatRoomEventTopic(channelID string) string {
+	const f = `stream-chat-room-v1.%s`
+	return fmt.Sprintf(f, channelID)
+}
+
+// chatRoomStates keeps the latest known ChatRoomState for each channel
+type chatRoomStates struct {
+	mutex  sync.Mutex
+	states map[string]ChatRoomState
+}
+
+func newChatRoomStates() *chatRoomStates {
+	return &chatRoomStates{
+		states: make(map[string]ChatRoomState),
+	}
+}
+
+func (s *chatRoomStates) update(channelID string, state ChatRoomState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.states[channelID] = state.clone()
+}
+
+func (s *chatRoomStates) get(channelID string) (ChatRoomState, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, ok := s.states[channelID]
+	return state.clone(), ok
+}
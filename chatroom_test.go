@@ -0,0 +1,202 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseChatRoomUpdate(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *ChatRoomUpdate
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	ten := 10
+	thirty := 30
+	slowModeSetAt := time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC)
+
+	testCases := []testCase{
+		{
+			label:      "All modes disabled",
+			input:      `{"type":"MESSAGE","data":{"topic":"stream-chat-room-v1.11148817","message":"{\"type\":\"updated_room\",\"data\":{\"room\":{\"channel_id\":\"11148817\",\"modes\":{\"followers_only_duration_minutes\":null,\"emote_only_mode_enabled\":false,\"r9k_mode_enabled\":false,\"subscribers_only_mode_enabled\":false,\"verified_only_mode_enabled\":false,\"slow_mode_duration_seconds\":null,\"slow_mode_set_at\":null},\"rules\":[],\"name\":\"pajlada\"}}}"}}`,
+			isValidMsg: true,
+			expected: &ChatRoomUpdate{
+				Type: "updated_room",
+				Room: ChatRoomState{
+					ChannelID: "11148817",
+					Name:      "pajlada",
+					Modes:     ChatRoomModes{},
+					Rules:     []string{},
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Followers & slow mode with rules",
+			input:      `{"type":"MESSAGE","data":{"topic":"stream-chat-room-v1.11148817","message":"{\"type\":\"updated_room\",\"data\":{\"room\":{\"channel_id\":\"11148817\",\"modes\":{\"followers_only_duration_minutes\":10,\"emote_only_mode_enabled\":true,\"r9k_mode_enabled\":false,\"subscribers_only_mode_enabled\":false,\"verified_only_mode_enabled\":false,\"slow_mode_duration_seconds\":30,\"slow_mode_set_at\":\"2023-06-17T15:04:31Z\"},\"rules\":[\"Be nice\"],\"name\":\"pajlada\"}}}"}}`,
+			isValidMsg: true,
+			expected: &ChatRoomUpdate{
+				Type: "updated_room",
+				Room: ChatRoomState{
+					ChannelID: "11148817",
+					Name:      "pajlada",
+					Modes: ChatRoomModes{
+						FollowersOnlyDurationMinutes: &ten,
+						EmoteOnlyModeEnabled:         true,
+						SlowModeDurationSeconds:      &thirty,
+						SlowModeSetAt:                &slowModeSetAt,
+					},
+					Rules: []string{"Be nice"},
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Message without room data",
+			input:            `{"type":"MESSAGE","data":{"topic":"stream-chat-room-v1.11148817","message":"{\"type\":\"chat_rich_embed\",\"data\":{\"message_id\":\"3f8a7d1e\",\"request_url\":\"https://clips.twitch.tv/forsen\"}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"stream-chat-room-v1.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isChatRoomEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseChatRoomUpdate(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestChatRoomStates(t *testing.T) {
+	c := qt.New(t)
+
+	states := newChatRoomStates()
+
+	_, ok := states.get("11148817")
+	c.Assert(ok, qt.IsFalse)
+
+	states.update("11148817", ChatRoomState{ChannelID: "11148817", Modes: ChatRoomModes{R9KModeEnabled: true}})
+	states.update("11148817", ChatRoomState{ChannelID: "11148817", Modes: ChatRoomModes{EmoteOnlyModeEnabled: true}})
+
+	state, ok := states.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(state.Modes, qt.DeepEquals, ChatRoomModes{EmoteOnlyModeEnabled: true})
+}
+
+func TestChatRoomStatesReturnsCopy(t *testing.T) {
+	c := qt.New(t)
+
+	states := newChatRoomStates()
+
+	thirty := 30
+	states.update("11148817", ChatRoomState{
+		ChannelID: "11148817",
+		Modes:     ChatRoomModes{SlowModeDurationSeconds: &thirty},
+		Rules:     []string{"Be nice"},
+	})
+	thirty = 60
+
+	state, ok := states.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	state.Rules[0] = "Be mean"
+	*state.Modes.SlowModeDurationSeconds = 120
+
+	state, ok = states.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(state.Rules, qt.DeepEquals, []string{"Be nice"})
+	c.Assert(*state.Modes.SlowModeDurationSeconds, qt.Equals, 30)
+}
+
+func TestClientChatRoomStateOnlyTracksUpdatedRoom(t *testing.T) {
+	c := qt.New(t)
+
+	client := NewClient(DefaultHost)
+
+	updates := make(chan *ChatRoomUpdate, 2)
+	client.OnChatRoomUpdate(func(channelID string, data *ChatRoomUpdate) {
+		updates <- data
+	})
+
+	go client.Start()
+	defer client.Disconnect()
+
+	topic := ChatRoomEventTopic("11148817")
+
+	client.messageBus <- sharedMessage{
+		Topic: topic,
+		Message: &ChatRoomUpdate{
+			Type: ChatRoomUpdateTypeUpdatedRoom,
+			Room: ChatRoomState{
+				ChannelID: "11148817",
+				Name:      "pajlada",
+				Modes:     ChatRoomModes{EmoteOnlyModeEnabled: true},
+			},
+		},
+	}
+	client.messageBus <- sharedMessage{
+		Topic: topic,
+		Message: &ChatRoomUpdate{
+			Type: "updated_room_partial",
+			Room: ChatRoomState{},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-time.After(time.Second):
+			c.Fatal("timed out waiting for chat room update")
+		}
+	}
+
+	state, ok := client.ChatRoomState("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(state.Name, qt.Equals, "pajlada")
+	c.Assert(state.Modes, qt.DeepEquals, ChatRoomModes{EmoteOnlyModeEnabled: true})
+}
+
+func TestParseChatRoomTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromChatRoomTopic(ChatRoomEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromChatRoomTopic("stream-chat-room-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from chat room topic")
+}
@@ -0,0 +1,118 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch chatrooms user events
+// These describe moderation actions taken against the authenticated user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const chatroomsUserEventTopicPrefix = "chatrooms-user-v1."
+
+// chatroomsUserMessageTypeModerationAction is the only message type on the chatrooms user topic that we handle
+const chatroomsUserMessageTypeModerationAction = "user_moderation_action"
+
+// Known values of ChatroomsUserModerationAction.Action
+const (
+	ChatroomsUserActionBan       = "ban"
+	ChatroomsUserActionUnban     = "unban"
+	ChatroomsUserActionTimeout   = "timeout"
+	ChatroomsUserActionUntimeout = "untimeout"
+)
+
+// ChatroomsUserModerationAction describes the authenticated user being banned, timed out, or unbanned in a channel, coming from Twitch's PubSub servers
+type ChatroomsUserModerationAction struct {
+	// Type is the kind of event, which is always "user_moderation_action"
+	Type string
+
+	// Action is one of the ChatroomsUserAction* constants
+	Action string
+
+	// ChannelID is the channel the action was taken in
+	ChannelID string
+
+	// TargetID is the ID of the user the action was taken against, which is the authenticated user
+	TargetID string
+
+	// ExpiresAt is when the timeout expires
+	// Zero if the action is not a timeout
+	ExpiresAt time.Time
+
+	// ExpiresIn is the duration of the timeout
+	// Zero if the action is not a timeout
+	ExpiresIn time.Duration
+
+	// Reason can be empty if no reason was given
+	Reason string
+
+	RawEvent
+}
+
+type outerChatroomsUserModerationAction struct {
+	Type string `json:"type"`
+	Data struct {
+		Action      string `json:"action"`
+		ChannelID   string `json:"channel_id"`
+		TargetID    string `json:"target_id"`
+		ExpiresAt   string `json:"expires_at"`
+		ExpiresInMs int64  `json:"expires_in_ms"`
+		Reason      string `json:"reason"`
+	} `json:"data"`
+}
+
+// parseChatroomsUserModerationAction parses any message sent on the chatrooms user topic
+// Returns nil without an error for message types we don't handle
+func parseChatroomsUserModerationAction(bytes []byte) (*ChatroomsUserModerationAction, error) {
+	data := &outerChatroomsUserModerationAction{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Type != chatroomsUserMessageTypeModerationAction {
+		return nil, nil
+	}
+
+	action := &ChatroomsUserModerationAction{
+		Type:      data.Type,
+		Action:    data.Data.Action,
+		ChannelID: data.Data.ChannelID,
+		TargetID:  data.Data.TargetID,
+		ExpiresIn: time.Duration(data.Data.ExpiresInMs) * time.Millisecond,
+		Reason:    data.Data.Reason,
+		RawEvent:  newRawEvent(bytes, data),
+	}
+
+	// expires_at is sent as an empty string for actions that don't expire
+	if data.Data.ExpiresAt != "" {
+		action.ExpiresAt, err = time.Parse(time.RFC3339Nano, data.Data.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return action, nil
+}
+
+func parseUserIDFromChatroomsUserTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse user ID from chatrooms user topic")
+	}
+
+	return parts[1], nil
+}
+
+func isChatroomsUserEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, chatroomsUserEventTopicPrefix)
+}
+
+// ChatroomsUserEventTopic returns a properly formatted chatrooms user event topic string with the given user ID argument
+func ChatroomsUserEventTopic(userID string) string {
+	const f = `chatrooms-user-v1.%s`
+	return fmt.Sprintf(f, userID)
+}
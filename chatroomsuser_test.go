@@ -0,0 +1,110 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseChatroomsUserModerationAction(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *ChatroomsUserModerationAction
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Timeout",
+			input:      `{"type":"MESSAGE","data":{"topic":"chatrooms-user-v1.133077169","message":"{\"type\":\"user_moderation_action\",\"data\":{\"action\":\"timeout\",\"channel_id\":\"11148817\",\"expires_at\":\"2023-06-17T15:14:31.20928599Z\",\"expires_in_ms\":600000,\"reason\":\"spam\",\"target_id\":\"133077169\"}}"}}`,
+			isValidMsg: true,
+			expected: &ChatroomsUserModerationAction{
+				Type:      "user_moderation_action",
+				Action:    ChatroomsUserActionTimeout,
+				ChannelID: "11148817",
+				TargetID:  "133077169",
+				ExpiresAt: time.Date(2023, time.June, 17, 15, 14, 31, 209285990, time.UTC),
+				ExpiresIn: 10 * time.Minute,
+				Reason:    "spam",
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Ban",
+			input:      `{"type":"MESSAGE","data":{"topic":"chatrooms-user-v1.133077169","message":"{\"type\":\"user_moderation_action\",\"data\":{\"action\":\"ban\",\"channel_id\":\"11148817\",\"expires_at\":\"\",\"expires_in_ms\":0,\"reason\":\"\",\"target_id\":\"133077169\"}}"}}`,
+			isValidMsg: true,
+			expected: &ChatroomsUserModerationAction{
+				Type:      "user_moderation_action",
+				Action:    ChatroomsUserActionBan,
+				ChannelID: "11148817",
+				TargetID:  "133077169",
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"chatrooms-user-v1.133077169","message":"{\"type\":\"channel_banned_alias_restriction_update\",\"data\":{\"user_is_restricted\":false,\"channel_id\":\"11148817\"}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid expiry",
+			input:            `{"type":"MESSAGE","data":{"topic":"chatrooms-user-v1.133077169","message":"{\"type\":\"user_moderation_action\",\"data\":{\"action\":\"timeout\",\"expires_at\":\"forsen\"}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New(`parsing time "forsen" as .*`),
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"chatrooms-user-v1.133077169","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isChatroomsUserEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseChatroomsUserModerationAction(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseChatroomsUserTopicUserID(t *testing.T) {
+	c := qt.New(t)
+
+	userID, err := parseUserIDFromChatroomsUserTopic(ChatroomsUserEventTopic("123"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, "123")
+
+	_, err = parseUserIDFromChatroomsUserTopic("chatrooms-user-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse user ID from chatrooms user topic")
+}
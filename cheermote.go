@@ -0,0 +1,96 @@
+package twitchpubsub
+
+// Helper functions and structures for splitting cheermotes out of bits messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrCheermoteBitsMismatch is returned when the cheermotes in a message do not add up to the bits used
+var ErrCheermoteBitsMismatch = errors.New("go-twitch-pubsub: Cheermote amounts do not add up to bits used")
+
+// ErrNoCheermotePrefixes is returned by LoadCheermoteTokenizer when the file does not contain any cheermote prefixes
+var ErrNoCheermotePrefixes = errors.New("go-twitch-pubsub: No cheermote prefixes found")
+
+// CheermoteTokenizer splits bits messages into cheermote and text fragments using a list of known cheermote prefixes
+type CheermoteTokenizer struct {
+	// Prefixes are the cheermote prefixes to recognize, e.g. "Cheer" or "PogChamp", matched case insensitively
+	Prefixes []string
+}
+
+// NewCheermoteTokenizer returns a tokenizer recognizing the given cheermote prefixes
+func NewCheermoteTokenizer(prefixes []string) *CheermoteTokenizer {
+	return &CheermoteTokenizer{
+		Prefixes: prefixes,
+	}
+}
+
+// LoadCheermoteTokenizer returns a tokenizer recognizing the cheermote prefixes in the given JSON file
+// The file contains either a list of prefixes, e.g. ["Cheer","PogChamp"], or a saved Helix Get Cheermotes response
+// ErrNoCheermotePrefixes is returned if the file contains no prefixes, since every cheer would then be reported as a mismatch
+func LoadCheermoteTokenizer(path string) (*CheermoteTokenizer, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	if err := json.Unmarshal(bytes, &prefixes); err != nil {
+		response := struct {
+			Data []struct {
+				Prefix string `json:"prefix"`
+			} `json:"data"`
+		}{}
+		if err := json.Unmarshal(bytes, &response); err != nil {
+			return nil, err
+		}
+
+		for _, cheermote := range response.Data {
+			prefixes = append(prefixes, cheermote.Prefix)
+		}
+	}
+
+	if len(prefixes) == 0 {
+		return nil, ErrNoCheermotePrefixes
+	}
+
+	return NewCheermoteTokenizer(prefixes), nil
+}
+
+// Tokenize splits the chat message of the given bits event into cheermote and text fragments
+// If the cheermote amounts do not add up to BitsUsed, the fragments are returned along with an error wrapping ErrCheermoteBitsMismatch
+func (t *CheermoteTokenizer) Tokenize(e *BitsEvent) ([]Fragment, error) {
+	fragments := splitWords(e.ChatMessage, func(word string) *Fragment {
+		return cheermoteFragment(word, t.Prefixes)
+	})
+
+	bits := 0
+	for _, fragment := range fragments {
+		bits += fragment.Bits
+	}
+
+	if bits != e.BitsUsed {
+		return fragments, fmt.Errorf("%w: got %d, expected %d", ErrCheermoteBitsMismatch, bits, e.BitsUsed)
+	}
+
+	return fragments, nil
+}
+
+// Strip returns the chat message of the given bits event with all cheermotes removed and whitespace collapsed
+// The error is the same as the one returned by Tokenize
+func (t *CheermoteTokenizer) Strip(e *BitsEvent) (string, error) {
+	fragments, err := t.Tokenize(e)
+
+	var text strings.Builder
+	for _, fragment := range fragments {
+		if fragment.Type != FragmentTypeCheermote {
+			text.WriteString(fragment.Text)
+		}
+	}
+
+	return strings.Join(strings.Fields(text.String()), " "), err
+}
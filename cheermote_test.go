@@ -0,0 +1,145 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCheermoteTokenizer(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label         string
+		input         *BitsEvent
+		expected      []Fragment
+		expectedStrip string
+		expectedErr   error
+	}
+
+	tokenizer := NewCheermoteTokenizer([]string{"Cheer", "PogChamp"})
+
+	testCases := []testCase{
+		{
+			label: "Cheers and text",
+			input: &BitsEvent{
+				BitsUsed:    600,
+				ChatMessage: "Cheer100 hello PogChamp500 world",
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeCheermote, Text: "Cheer100", CheermotePrefix: "Cheer", Bits: 100},
+				{Type: FragmentTypeText, Text: " hello "},
+				{Type: FragmentTypeCheermote, Text: "PogChamp500", CheermotePrefix: "PogChamp", Bits: 500},
+				{Type: FragmentTypeText, Text: " world"},
+			},
+			expectedStrip: "hello world",
+		},
+		{
+			label: "Unknown prefixes are text",
+			input: &BitsEvent{
+				BitsUsed:    1,
+				ChatMessage: "cheer1 Kappa100",
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeCheermote, Text: "cheer1", CheermotePrefix: "Cheer", Bits: 1},
+				{Type: FragmentTypeText, Text: " Kappa100"},
+			},
+			expectedStrip: "Kappa100",
+		},
+		{
+			label: "Amounts do not add up",
+			input: &BitsEvent{
+				BitsUsed:    200,
+				ChatMessage: "Cheer100",
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeCheermote, Text: "Cheer100", CheermotePrefix: "Cheer", Bits: 100},
+			},
+			expectedStrip: "",
+			expectedErr:   errors.New("go-twitch-pubsub: Cheermote amounts do not add up to bits used: got 100, expected 200"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual, err := tokenizer.Tokenize(testCase.input)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				c.Assert(errors.Is(err, ErrCheermoteBitsMismatch), qt.IsTrue)
+			}
+			c.Assert(actual, qt.DeepEquals, testCase.expected)
+
+			stripped, _ := tokenizer.Strip(testCase.input)
+			c.Assert(stripped, qt.Equals, testCase.expectedStrip)
+		})
+	}
+}
+
+func TestLoadCheermoteTokenizer(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label       string
+		input       string
+		expected    []string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:    "Prefix list",
+			input:    `["Cheer","PogChamp"]`,
+			expected: []string{"Cheer", "PogChamp"},
+		},
+		{
+			label:    "Helix response",
+			input:    `{"data":[{"prefix":"Cheer","tiers":[],"type":"global_first_party"},{"prefix":"pajaCheer","tiers":[],"type":"channel_custom"}]}`,
+			expected: []string{"Cheer", "pajaCheer"},
+		},
+		{
+			label:       "Empty object",
+			input:       `{}`,
+			expectedErr: ErrNoCheermotePrefixes,
+		},
+		{
+			label:       "Null",
+			input:       `null`,
+			expectedErr: ErrNoCheermotePrefixes,
+		},
+		{
+			label:       "Object without data",
+			input:       `{"error":"Unauthorized","status":401,"message":"OAuth token is missing"}`,
+			expectedErr: ErrNoCheermotePrefixes,
+		},
+		{
+			label:       "Empty prefix list",
+			input:       `[]`,
+			expectedErr: ErrNoCheermotePrefixes,
+		},
+		{
+			label:       "Invalid JSON",
+			input:       `{forsen}`,
+			expectedErr: errors.New("invalid character 'f' looking for beginning of object key string"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			path := filepath.Join(c.TempDir(), "cheermotes.json")
+			c.Assert(os.WriteFile(path, []byte(testCase.input), 0o600), qt.IsNil)
+
+			actual, err := LoadCheermoteTokenizer(path)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+				c.Assert(actual.Prefixes, qt.DeepEquals, testCase.expected)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+		})
+	}
+}
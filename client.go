@@ -37,17 +37,59 @@ type messageBusType chan sharedMessage
 // Client is the client that connects to Twitch's pubsub servers
 type Client struct {
 	// Callbacks
-	onModerationAction  func(channelID string, data *ModerationAction)
-	onBitsEvent         func(channelID string, data *BitsEvent)
-	onPointsEvent       func(channelID string, data *PointsEvent)
-	onAutoModQueueEvent func(channelID string, data *AutoModQueueEvent)
-	onWhisperEvent      func(userID string, data *WhisperEvent)
-	onSubscribeEvent    func(channelID string, data *SubscribeEvent)
+	onModerationAction                func(channelID string, data *ModerationAction)
+	onRoleChangeEvent                 func(channelID string, data *RoleChangeEvent)
+	onChannelTermsEvent               func(channelID string, data *ChannelTermsEvent)
+	onUnbanRequestActionEvent         func(channelID string, data *UnbanRequestActionEvent)
+	onBitsEvent                       func(channelID string, data *BitsEvent)
+	onPointsEvent                     func(channelID string, data *PointsEvent)
+	onAutoModQueueEvent               func(channelID string, data *AutoModQueueEvent)
+	onWhisperEvent                    func(userID string, data *WhisperEvent)
+	onSubscribeEvent                  func(channelID string, data *SubscribeEvent)
+	onBitsBadgeUnlockEvent            func(channelID string, data *BitsBadgeUnlockEvent)
+	onLowTrustUserEvent               func(channelID string, data *LowTrustUserEvent)
+	onUserModerationNotificationEvent func(channelID string, data *UserModerationNotificationEvent)
+	onRaidGo                          func(channelID string, data *RaidGo)
+	onRaidUpdate                      func(channelID string, data *RaidUpdate)
+	onRaidCancel                      func(channelID string, data *RaidCancel)
+	onMysteryGiftEvent                func(channelID string, data *MysteryGiftEvent)
+	onGiftBatch                       func(channelID string, data *GiftBatch)
+	onLeaderboardUpdate               func(channelID string, data *LeaderboardUpdate)
+	onChatRoomUpdate                  func(channelID string, data *ChatRoomUpdate)
+	onBroadcastSettingsUpdate         func(channelID string, data *BroadcastSettingsUpdate)
+	onChatroomsUserModerationAction   func(userID string, data *ChatroomsUserModerationAction)
+	onShoutoutCreate                  func(channelID string, data *ShoutoutCreate)
+	onShoutoutReceived                func(channelID string, data *ShoutoutReceived)
+	onPinCreated                      func(channelID string, data *PinCreated)
+	onPinUpdated                      func(channelID string, data *PinUpdated)
+	onPinDeleted                      func(channelID string, data *PinDeleted)
+	onUnbanRequestCreate              func(channelID string, data *UnbanRequestCreate)
+	onUnbanRequestUpdate              func(channelID string, data *UnbanRequestUpdate)
+	onGoalCreated                     func(channelID string, data *GoalCreated)
+	onGoalUpdated                     func(channelID string, data *GoalUpdated)
+	onGoalAchieved                    func(channelID string, data *GoalAchieved)
+	onGoalEnded                       func(channelID string, data *GoalEnded)
+	onCharityDonationEvent            func(channelID string, data *CharityDonationEvent)
+	onUserSubscribeEvent              func(userID string, data *UserSubscribeEvent)
+	onCommunityPointsEarned           func(userID string, data *CommunityPointsEarned)
+	onCommunityPointsSpent            func(userID string, data *CommunityPointsSpent)
+	onCommunityPointsClaimAvailable   func(userID string, data *CommunityPointsClaimAvailable)
+	onExtensionMessage                func(channelID string, data *ExtensionMessage)
+	onWhisperSentEvent                func(userID string, data *WhisperSentEvent)
+	onWhisperThreadEvent              func(userID string, data *WhisperThreadEvent)
 
 	connectionManager *connectionManager
 
 	topics *topicManager
 
+	giftBatchAggregator *giftBatchAggregator
+
+	chatRoomStates *chatRoomStates
+
+	pinnedChatTracker *pinnedChatTracker
+
+	unbanRequestQueue *unbanRequestQueue
+
 	messageBus chan sharedMessage
 
 	quitChannel chan struct{}
@@ -64,6 +106,12 @@ func NewClient(host string) *Client {
 
 		topics: newTopicManager(),
 
+		chatRoomStates: newChatRoomStates(),
+
+		pinnedChatTracker: newPinnedChatTracker(),
+
+		unbanRequestQueue: newUnbanRequestQueue(),
+
 		connectionManager: newConnectionManager(host, defaultConnectionLimit, defaultTopicLimit, messageBus, quitChannel),
 	}
 }
@@ -81,6 +129,21 @@ func (c *Client) OnModerationAction(callback func(channelID string, data *Modera
 	c.onModerationAction = callback
 }
 
+// OnRoleChangeEvent attaches the given callback to the moderator added, moderator removed & VIP added events from the moderation topic
+func (c *Client) OnRoleChangeEvent(callback func(channelID string, data *RoleChangeEvent)) {
+	c.onRoleChangeEvent = callback
+}
+
+// OnChannelTermsEvent attaches the given callback to the blocked & permitted terms event from the moderation topic
+func (c *Client) OnChannelTermsEvent(callback func(channelID string, data *ChannelTermsEvent)) {
+	c.onChannelTermsEvent = callback
+}
+
+// OnUnbanRequestActionEvent attaches the given callback to the unban request approved & denied events from the moderation topic
+func (c *Client) OnUnbanRequestActionEvent(callback func(channelID string, data *UnbanRequestActionEvent)) {
+	c.onUnbanRequestActionEvent = callback
+}
+
 // OnBitsEvent attaches the given callback to the bits event
 func (c *Client) OnBitsEvent(callback func(channelID string, data *BitsEvent)) {
 	c.onBitsEvent = callback
@@ -106,6 +169,194 @@ func (c *Client) OnSubscribeEvent(callback func(channelID string, data *Subscrib
 	c.onSubscribeEvent = callback
 }
 
+// OnGiftBatch attaches the given callback to batches of gift subscriptions from the subscribe topic
+// Gift subscriptions from the same gifter are grouped into one batch until no new gift has been seen for the duration of window
+// Every gift subscription is still delivered to the OnSubscribeEvent callback as well
+// This must be called before Start
+func (c *Client) OnGiftBatch(window time.Duration, callback func(channelID string, data *GiftBatch)) {
+	c.onGiftBatch = callback
+	c.giftBatchAggregator = newGiftBatchAggregator(window, func(batch *GiftBatch) {
+		select {
+		case c.messageBus <- sharedMessage{
+			Topic:   SubscribeEventTopic(batch.ChannelID),
+			Message: batch,
+		}:
+		case <-c.quitChannel:
+		}
+	})
+}
+
+// OnBitsBadgeUnlockEvent attaches the given callback to the bits badge unlock event
+func (c *Client) OnBitsBadgeUnlockEvent(callback func(channelID string, data *BitsBadgeUnlockEvent)) {
+	c.onBitsBadgeUnlockEvent = callback
+}
+
+// OnLowTrustUserEvent attaches the given callback to the low trust user event
+func (c *Client) OnLowTrustUserEvent(callback func(channelID string, data *LowTrustUserEvent)) {
+	c.onLowTrustUserEvent = callback
+}
+
+// OnUserModerationNotificationEvent attaches the given callback to the user moderation notification event
+func (c *Client) OnUserModerationNotificationEvent(callback func(channelID string, data *UserModerationNotificationEvent)) {
+	c.onUserModerationNotificationEvent = callback
+}
+
+// OnRaidGo attaches the given callback to the raid go event
+func (c *Client) OnRaidGo(callback func(channelID string, data *RaidGo)) {
+	c.onRaidGo = callback
+}
+
+// OnRaidUpdate attaches the given callback to the raid update event
+func (c *Client) OnRaidUpdate(callback func(channelID string, data *RaidUpdate)) {
+	c.onRaidUpdate = callback
+}
+
+// OnRaidCancel attaches the given callback to the raid cancel event
+func (c *Client) OnRaidCancel(callback func(channelID string, data *RaidCancel)) {
+	c.onRaidCancel = callback
+}
+
+// OnMysteryGiftEvent attaches the given callback to the mystery gift event
+func (c *Client) OnMysteryGiftEvent(callback func(channelID string, data *MysteryGiftEvent)) {
+	c.onMysteryGiftEvent = callback
+}
+
+// OnLeaderboardUpdate attaches the given callback to the leaderboard update event
+func (c *Client) OnLeaderboardUpdate(callback func(channelID string, data *LeaderboardUpdate)) {
+	c.onLeaderboardUpdate = callback
+}
+
+// OnChatRoomUpdate attaches the given callback to the chat room update event
+func (c *Client) OnChatRoomUpdate(callback func(channelID string, data *ChatRoomUpdate)) {
+	c.onChatRoomUpdate = callback
+}
+
+// ChatRoomState returns the latest known settings of the given channel's chat room
+// The state is kept up to date from events on the chat room topic, so the topic must be listened to
+// Returns false if no chat room update has been received for the channel yet
+func (c *Client) ChatRoomState(channelID string) (ChatRoomState, bool) {
+	return c.chatRoomStates.get(channelID)
+}
+
+// OnBroadcastSettingsUpdate attaches the given callback to the broadcast settings update event
+func (c *Client) OnBroadcastSettingsUpdate(callback func(channelID string, data *BroadcastSettingsUpdate)) {
+	c.onBroadcastSettingsUpdate = callback
+}
+
+// OnChatroomsUserModerationAction attaches the given callback to the chatrooms user moderation action event
+func (c *Client) OnChatroomsUserModerationAction(callback func(userID string, data *ChatroomsUserModerationAction)) {
+	c.onChatroomsUserModerationAction = callback
+}
+
+// OnShoutoutCreate attaches the given callback to the shoutout create event
+func (c *Client) OnShoutoutCreate(callback func(channelID string, data *ShoutoutCreate)) {
+	c.onShoutoutCreate = callback
+}
+
+// OnShoutoutReceived attaches the given callback to the shoutout received event
+func (c *Client) OnShoutoutReceived(callback func(channelID string, data *ShoutoutReceived)) {
+	c.onShoutoutReceived = callback
+}
+
+// OnPinCreated attaches the given callback to the pin created event
+func (c *Client) OnPinCreated(callback func(channelID string, data *PinCreated)) {
+	c.onPinCreated = callback
+}
+
+// OnPinUpdated attaches the given callback to the pin updated event
+func (c *Client) OnPinUpdated(callback func(channelID string, data *PinUpdated)) {
+	c.onPinUpdated = callback
+}
+
+// OnPinDeleted attaches the given callback to the pin deleted event
+func (c *Client) OnPinDeleted(callback func(channelID string, data *PinDeleted)) {
+	c.onPinDeleted = callback
+}
+
+// PinnedMessage returns the message currently pinned in the given channel
+// The pin is kept up to date from events on the pinned chat topic, so the topic must be listened to
+// Returns false if no message is pinned, or if the pinned message has expired
+func (c *Client) PinnedMessage(channelID string) (PinCreated, bool) {
+	return c.pinnedChatTracker.get(channelID)
+}
+
+// OnUnbanRequestCreate attaches the given callback to the unban request create event
+func (c *Client) OnUnbanRequestCreate(callback func(channelID string, data *UnbanRequestCreate)) {
+	c.onUnbanRequestCreate = callback
+}
+
+// OnUnbanRequestUpdate attaches the given callback to the unban request update event
+func (c *Client) OnUnbanRequestUpdate(callback func(channelID string, data *UnbanRequestUpdate)) {
+	c.onUnbanRequestUpdate = callback
+}
+
+// PendingUnbanRequests returns the unresolved unban requests of the given channel, oldest first
+// The queue is kept up to date from events on the unban requests topic, so the topic must be listened to
+// Only requests created after the topic was listened to are known
+func (c *Client) PendingUnbanRequests(channelID string) []UnbanRequest {
+	return c.unbanRequestQueue.get(channelID)
+}
+
+// OnGoalCreated attaches the given callback to the goal created event
+func (c *Client) OnGoalCreated(callback func(channelID string, data *GoalCreated)) {
+	c.onGoalCreated = callback
+}
+
+// OnGoalUpdated attaches the given callback to the goal updated event
+func (c *Client) OnGoalUpdated(callback func(channelID string, data *GoalUpdated)) {
+	c.onGoalUpdated = callback
+}
+
+// OnGoalAchieved attaches the given callback to the goal achieved event
+func (c *Client) OnGoalAchieved(callback func(channelID string, data *GoalAchieved)) {
+	c.onGoalAchieved = callback
+}
+
+// OnGoalEnded attaches the given callback to the goal ended event
+func (c *Client) OnGoalEnded(callback func(channelID string, data *GoalEnded)) {
+	c.onGoalEnded = callback
+}
+
+// OnCharityDonationEvent attaches the given callback to the charity donation event
+func (c *Client) OnCharityDonationEvent(callback func(channelID string, data *CharityDonationEvent)) {
+	c.onCharityDonationEvent = callback
+}
+
+// OnUserSubscribeEvent attaches the given callback to the user subscribe event
+func (c *Client) OnUserSubscribeEvent(callback func(userID string, data *UserSubscribeEvent)) {
+	c.onUserSubscribeEvent = callback
+}
+
+// OnCommunityPointsEarned attaches the given callback to the community points earned event
+func (c *Client) OnCommunityPointsEarned(callback func(userID string, data *CommunityPointsEarned)) {
+	c.onCommunityPointsEarned = callback
+}
+
+// OnCommunityPointsSpent attaches the given callback to the community points spent event
+func (c *Client) OnCommunityPointsSpent(callback func(userID string, data *CommunityPointsSpent)) {
+	c.onCommunityPointsSpent = callback
+}
+
+// OnCommunityPointsClaimAvailable attaches the given callback to the community points claim available event
+func (c *Client) OnCommunityPointsClaimAvailable(callback func(userID string, data *CommunityPointsClaimAvailable)) {
+	c.onCommunityPointsClaimAvailable = callback
+}
+
+// OnExtensionMessage attaches the given callback to the extension message event
+func (c *Client) OnExtensionMessage(callback func(channelID string, data *ExtensionMessage)) {
+	c.onExtensionMessage = callback
+}
+
+// OnWhisperSentEvent attaches the given callback to the whisper sent event
+func (c *Client) OnWhisperSentEvent(callback func(userID string, data *WhisperSentEvent)) {
+	c.onWhisperSentEvent = callback
+}
+
+// OnWhisperThreadEvent attaches the given callback to the whisper thread event
+func (c *Client) OnWhisperThreadEvent(callback func(userID string, data *WhisperThreadEvent)) {
+	c.onWhisperThreadEvent = callback
+}
+
 // Connect starts attempting to connect to the pubsub host
 func (c *Client) Start() error {
 	go c.connectionManager.run()
@@ -121,7 +372,47 @@ func (c *Client) Start() error {
 					log.Println("Error parsing channel id from moderation topic:", err)
 					continue
 				}
-				c.onModerationAction(channelID, d)
+				if c.onModerationAction != nil {
+					c.onModerationAction(channelID, d)
+				} else {
+					log.Println("Subscribed to ModerationAction but no callback is attached")
+				}
+			case *RoleChangeEvent:
+				d := msg.Message.(*RoleChangeEvent)
+				channelID, err := parseChannelIDFromModerationTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from moderation topic:", err)
+					continue
+				}
+				if c.onRoleChangeEvent != nil {
+					c.onRoleChangeEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to RoleChangeEvent but no callback is attached")
+				}
+			case *ChannelTermsEvent:
+				d := msg.Message.(*ChannelTermsEvent)
+				channelID, err := parseChannelIDFromModerationTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from moderation topic:", err)
+					continue
+				}
+				if c.onChannelTermsEvent != nil {
+					c.onChannelTermsEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to ChannelTermsEvent but no callback is attached")
+				}
+			case *UnbanRequestActionEvent:
+				d := msg.Message.(*UnbanRequestActionEvent)
+				channelID, err := parseChannelIDFromModerationTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from moderation topic:", err)
+					continue
+				}
+				if c.onUnbanRequestActionEvent != nil {
+					c.onUnbanRequestActionEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to UnbanRequestActionEvent but no callback is attached")
+				}
 			case *BitsEvent:
 				d := msg.Message.(*BitsEvent)
 				channelID, err := parseChannelIDFromBitsTopic(msg.Topic)
@@ -141,7 +432,11 @@ func (c *Client) Start() error {
 					log.Println("Error parsing channel id from points topic:", err)
 					continue
 				}
-				c.onPointsEvent(channelID, d)
+				if c.onPointsEvent != nil {
+					c.onPointsEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to PointsEvent but no callback is attached")
+				}
 			case *AutoModQueueEvent:
 				d := msg.Message.(*AutoModQueueEvent)
 				channelID, err := parseChannelIDFromAutoModQueueTopic(msg.Topic)
@@ -149,7 +444,11 @@ func (c *Client) Start() error {
 					log.Println("Error parsing channel id from AutoMod Queue topic:", err)
 					continue
 				}
-				c.onAutoModQueueEvent(channelID, d)
+				if c.onAutoModQueueEvent != nil {
+					c.onAutoModQueueEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to AutoModQueueEvent but no callback is attached")
+				}
 			case *WhisperEvent:
 				d := msg.Message.(*WhisperEvent)
 				userID, err := parseUserIDFromWhisperTopic(msg.Topic)
@@ -157,7 +456,11 @@ func (c *Client) Start() error {
 					log.Println("Error parsing channel id from whisper topic:", err)
 					continue
 				}
-				c.onWhisperEvent(userID, d)
+				if c.onWhisperEvent != nil {
+					c.onWhisperEvent(userID, d)
+				} else {
+					log.Println("Subscribed to WhisperEvent but no callback is attached")
+				}
 			case *SubscribeEvent:
 				d := msg.Message.(*SubscribeEvent)
 				channelID, err := parseChannelIDFromSubscribeTopic(msg.Topic)
@@ -165,7 +468,396 @@ func (c *Client) Start() error {
 					log.Println("Error parsing channel id from subscribe topic:", err)
 					continue
 				}
-				c.onSubscribeEvent(channelID, d)
+				if c.onSubscribeEvent != nil {
+					c.onSubscribeEvent(channelID, d)
+				} else if c.giftBatchAggregator == nil {
+					log.Println("Subscribed to SubscribeEvent but no callback is attached")
+				}
+				if c.giftBatchAggregator != nil {
+					c.giftBatchAggregator.add(d)
+				}
+			case *GiftBatch:
+				d := msg.Message.(*GiftBatch)
+				channelID, err := parseChannelIDFromSubscribeTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from subscribe topic:", err)
+					continue
+				}
+				if c.onGiftBatch != nil {
+					c.onGiftBatch(channelID, d)
+				} else {
+					log.Println("Subscribed to GiftBatch but no callback is attached")
+				}
+			case *BitsBadgeUnlockEvent:
+				d := msg.Message.(*BitsBadgeUnlockEvent)
+				channelID, err := parseChannelIDFromBitsBadgeUnlockTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from bits badge unlock topic:", err)
+					continue
+				}
+				if c.onBitsBadgeUnlockEvent != nil {
+					c.onBitsBadgeUnlockEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to BitsBadgeUnlockEvent but no callback is attached")
+				}
+			case *LowTrustUserEvent:
+				d := msg.Message.(*LowTrustUserEvent)
+				channelID, err := parseChannelIDFromLowTrustUsersTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from low trust users topic:", err)
+					continue
+				}
+				if c.onLowTrustUserEvent != nil {
+					c.onLowTrustUserEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to LowTrustUserEvent but no callback is attached")
+				}
+			case *UserModerationNotificationEvent:
+				d := msg.Message.(*UserModerationNotificationEvent)
+				channelID, err := parseChannelIDFromUserModerationNotificationTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from user moderation notification topic:", err)
+					continue
+				}
+				if c.onUserModerationNotificationEvent != nil {
+					c.onUserModerationNotificationEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to UserModerationNotificationEvent but no callback is attached")
+				}
+			case *RaidGo:
+				d := msg.Message.(*RaidGo)
+				channelID, err := parseChannelIDFromRaidTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from raid topic:", err)
+					continue
+				}
+				if c.onRaidGo != nil {
+					c.onRaidGo(channelID, d)
+				} else {
+					log.Println("Subscribed to RaidGo but no callback is attached")
+				}
+			case *RaidUpdate:
+				d := msg.Message.(*RaidUpdate)
+				channelID, err := parseChannelIDFromRaidTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from raid topic:", err)
+					continue
+				}
+				if c.onRaidUpdate != nil {
+					c.onRaidUpdate(channelID, d)
+				} else {
+					log.Println("Subscribed to RaidUpdate but no callback is attached")
+				}
+			case *RaidCancel:
+				d := msg.Message.(*RaidCancel)
+				channelID, err := parseChannelIDFromRaidTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from raid topic:", err)
+					continue
+				}
+				if c.onRaidCancel != nil {
+					c.onRaidCancel(channelID, d)
+				} else {
+					log.Println("Subscribed to RaidCancel but no callback is attached")
+				}
+			case *MysteryGiftEvent:
+				d := msg.Message.(*MysteryGiftEvent)
+				channelID, err := parseChannelIDFromSubGiftsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from sub gifts topic:", err)
+					continue
+				}
+				if c.onMysteryGiftEvent != nil {
+					c.onMysteryGiftEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to MysteryGiftEvent but no callback is attached")
+				}
+			case *LeaderboardUpdate:
+				d := msg.Message.(*LeaderboardUpdate)
+				channelID, err := parseChannelIDFromLeaderboardTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from leaderboard topic:", err)
+					continue
+				}
+				if c.onLeaderboardUpdate != nil {
+					c.onLeaderboardUpdate(channelID, d)
+				} else {
+					log.Println("Subscribed to LeaderboardUpdate but no callback is attached")
+				}
+			case *ChatRoomUpdate:
+				d := msg.Message.(*ChatRoomUpdate)
+				channelID, err := parseChannelIDFromChatRoomTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from chat room topic:", err)
+					continue
+				}
+				if d.Type == ChatRoomUpdateTypeUpdatedRoom {
+					c.chatRoomStates.update(channelID, d.Room)
+				}
+				if c.onChatRoomUpdate != nil {
+					c.onChatRoomUpdate(channelID, d)
+				} else {
+					log.Println("Subscribed to ChatRoomUpdate but no callback is attached")
+				}
+			case *BroadcastSettingsUpdate:
+				d := msg.Message.(*BroadcastSettingsUpdate)
+				channelID, err := parseChannelIDFromBroadcastSettingsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from broadcast settings topic:", err)
+					continue
+				}
+				if c.onBroadcastSettingsUpdate != nil {
+					c.onBroadcastSettingsUpdate(channelID, d)
+				} else {
+					log.Println("Subscribed to BroadcastSettingsUpdate but no callback is attached")
+				}
+			case *ChatroomsUserModerationAction:
+				d := msg.Message.(*ChatroomsUserModerationAction)
+				userID, err := parseUserIDFromChatroomsUserTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from chatrooms user topic:", err)
+					continue
+				}
+				if c.onChatroomsUserModerationAction != nil {
+					c.onChatroomsUserModerationAction(userID, d)
+				} else {
+					log.Println("Subscribed to ChatroomsUserModerationAction but no callback is attached")
+				}
+			case *ShoutoutCreate:
+				d := msg.Message.(*ShoutoutCreate)
+				channelID, err := parseChannelIDFromShoutoutTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from shoutout topic:", err)
+					continue
+				}
+				if c.onShoutoutCreate != nil {
+					c.onShoutoutCreate(channelID, d)
+				} else {
+					log.Println("Subscribed to ShoutoutCreate but no callback is attached")
+				}
+			case *ShoutoutReceived:
+				d := msg.Message.(*ShoutoutReceived)
+				channelID, err := parseChannelIDFromShoutoutTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from shoutout topic:", err)
+					continue
+				}
+				if c.onShoutoutReceived != nil {
+					c.onShoutoutReceived(channelID, d)
+				} else {
+					log.Println("Subscribed to ShoutoutReceived but no callback is attached")
+				}
+			case *PinCreated:
+				d := msg.Message.(*PinCreated)
+				channelID, err := parseChannelIDFromPinnedChatTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from pinned chat topic:", err)
+					continue
+				}
+				c.pinnedChatTracker.created(channelID, d)
+				if c.onPinCreated != nil {
+					c.onPinCreated(channelID, d)
+				} else {
+					log.Println("Subscribed to PinCreated but no callback is attached")
+				}
+			case *PinUpdated:
+				d := msg.Message.(*PinUpdated)
+				channelID, err := parseChannelIDFromPinnedChatTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from pinned chat topic:", err)
+					continue
+				}
+				c.pinnedChatTracker.updated(channelID, d)
+				if c.onPinUpdated != nil {
+					c.onPinUpdated(channelID, d)
+				} else {
+					log.Println("Subscribed to PinUpdated but no callback is attached")
+				}
+			case *PinDeleted:
+				d := msg.Message.(*PinDeleted)
+				channelID, err := parseChannelIDFromPinnedChatTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from pinned chat topic:", err)
+					continue
+				}
+				c.pinnedChatTracker.deleted(channelID, d)
+				if c.onPinDeleted != nil {
+					c.onPinDeleted(channelID, d)
+				} else {
+					log.Println("Subscribed to PinDeleted but no callback is attached")
+				}
+			case *UnbanRequestCreate:
+				d := msg.Message.(*UnbanRequestCreate)
+				channelID, err := parseChannelIDFromUnbanRequestsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from unban requests topic:", err)
+					continue
+				}
+				c.unbanRequestQueue.created(channelID, d)
+				if c.onUnbanRequestCreate != nil {
+					c.onUnbanRequestCreate(channelID, d)
+				} else {
+					log.Println("Subscribed to UnbanRequestCreate but no callback is attached")
+				}
+			case *UnbanRequestUpdate:
+				d := msg.Message.(*UnbanRequestUpdate)
+				channelID, err := parseChannelIDFromUnbanRequestsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from unban requests topic:", err)
+					continue
+				}
+				c.unbanRequestQueue.updated(channelID, d)
+				if c.onUnbanRequestUpdate != nil {
+					c.onUnbanRequestUpdate(channelID, d)
+				} else {
+					log.Println("Subscribed to UnbanRequestUpdate but no callback is attached")
+				}
+			case *GoalCreated:
+				d := msg.Message.(*GoalCreated)
+				channelID, err := parseChannelIDFromCreatorGoalsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from creator goals topic:", err)
+					continue
+				}
+				if c.onGoalCreated != nil {
+					c.onGoalCreated(channelID, d)
+				} else {
+					log.Println("Subscribed to GoalCreated but no callback is attached")
+				}
+			case *GoalUpdated:
+				d := msg.Message.(*GoalUpdated)
+				channelID, err := parseChannelIDFromCreatorGoalsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from creator goals topic:", err)
+					continue
+				}
+				if c.onGoalUpdated != nil {
+					c.onGoalUpdated(channelID, d)
+				} else {
+					log.Println("Subscribed to GoalUpdated but no callback is attached")
+				}
+			case *GoalAchieved:
+				d := msg.Message.(*GoalAchieved)
+				channelID, err := parseChannelIDFromCreatorGoalsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from creator goals topic:", err)
+					continue
+				}
+				if c.onGoalAchieved != nil {
+					c.onGoalAchieved(channelID, d)
+				} else {
+					log.Println("Subscribed to GoalAchieved but no callback is attached")
+				}
+			case *GoalEnded:
+				d := msg.Message.(*GoalEnded)
+				channelID, err := parseChannelIDFromCreatorGoalsTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from creator goals topic:", err)
+					continue
+				}
+				if c.onGoalEnded != nil {
+					c.onGoalEnded(channelID, d)
+				} else {
+					log.Println("Subscribed to GoalEnded but no callback is attached")
+				}
+			case *CharityDonationEvent:
+				d := msg.Message.(*CharityDonationEvent)
+				channelID, err := parseChannelIDFromCharityDonationTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing channel id from charity donation topic:", err)
+					continue
+				}
+				if c.onCharityDonationEvent != nil {
+					c.onCharityDonationEvent(channelID, d)
+				} else {
+					log.Println("Subscribed to CharityDonationEvent but no callback is attached")
+				}
+			case *UserSubscribeEvent:
+				d := msg.Message.(*UserSubscribeEvent)
+				userID, err := parseUserIDFromUserSubscribeTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from user subscribe topic:", err)
+					continue
+				}
+				if c.onUserSubscribeEvent != nil {
+					c.onUserSubscribeEvent(userID, d)
+				} else {
+					log.Println("Subscribed to UserSubscribeEvent but no callback is attached")
+				}
+			case *CommunityPointsEarned:
+				d := msg.Message.(*CommunityPointsEarned)
+				userID, err := parseUserIDFromCommunityPointsUserTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from community points user topic:", err)
+					continue
+				}
+				if c.onCommunityPointsEarned != nil {
+					c.onCommunityPointsEarned(userID, d)
+				} else {
+					log.Println("Subscribed to CommunityPointsEarned but no callback is attached")
+				}
+			case *CommunityPointsSpent:
+				d := msg.Message.(*CommunityPointsSpent)
+				userID, err := parseUserIDFromCommunityPointsUserTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from community points user topic:", err)
+					continue
+				}
+				if c.onCommunityPointsSpent != nil {
+					c.onCommunityPointsSpent(userID, d)
+				} else {
+					log.Println("Subscribed to CommunityPointsSpent but no callback is attached")
+				}
+			case *CommunityPointsClaimAvailable:
+				d := msg.Message.(*CommunityPointsClaimAvailable)
+				userID, err := parseUserIDFromCommunityPointsUserTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from community points user topic:", err)
+					continue
+				}
+				if c.onCommunityPointsClaimAvailable != nil {
+					c.onCommunityPointsClaimAvailable(userID, d)
+				} else {
+					log.Println("Subscribed to CommunityPointsClaimAvailable but no callback is attached")
+				}
+			case *ExtensionMessage:
+				d := msg.Message.(*ExtensionMessage)
+				channelID, extensionID, target, err := parseExtensionTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing extension topic:", err)
+					continue
+				}
+				d.ExtensionID = extensionID
+				d.Target = target
+				if c.onExtensionMessage != nil {
+					c.onExtensionMessage(channelID, d)
+				} else {
+					log.Println("Subscribed to ExtensionMessage but no callback is attached")
+				}
+			case *WhisperSentEvent:
+				d := msg.Message.(*WhisperSentEvent)
+				userID, err := parseUserIDFromWhisperTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from whisper topic:", err)
+					continue
+				}
+				if c.onWhisperSentEvent != nil {
+					c.onWhisperSentEvent(userID, d)
+				} else {
+					log.Println("Subscribed to WhisperSentEvent but no callback is attached")
+				}
+			case *WhisperThreadEvent:
+				d := msg.Message.(*WhisperThreadEvent)
+				userID, err := parseUserIDFromWhisperTopic(msg.Topic)
+				if err != nil {
+					log.Println("Error parsing user id from whisper topic:", err)
+					continue
+				}
+				if c.onWhisperThreadEvent != nil {
+					c.onWhisperThreadEvent(userID, d)
+				} else {
+					log.Println("Subscribed to WhisperThreadEvent but no callback is attached")
+				}
 			default:
 				log.Println("unknown message in message bus")
 			}
@@ -0,0 +1,138 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch community points user events
+// These describe the authenticated user's own channel points
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const communityPointsUserEventTopicPrefix = "community-points-user-v1."
+
+// Known values of the outer message type on the community points user topic
+const (
+	communityPointsUserMessageTypePointsEarned   = "points-earned"
+	communityPointsUserMessageTypePointsSpent    = "points-spent"
+	communityPointsUserMessageTypeClaimAvailable = "claim-available"
+)
+
+// CommunityPointsBalance describes the user's channel points balance in a channel
+type CommunityPointsBalance struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	Balance   int    `json:"balance"`
+}
+
+// CommunityPointsGain describes an amount of channel points earned by the user
+type CommunityPointsGain struct {
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+
+	TotalPoints    int `json:"total_points"`
+	BaselinePoints int `json:"baseline_points"`
+
+	// ReasonCode is why the points were earned, e.g. "WATCH", "CLAIM" or "RAID"
+	ReasonCode string `json:"reason_code"`
+
+	Multipliers []CommunityPointsMultiplier `json:"multipliers"`
+}
+
+// CommunityPointsMultiplier describes a bonus applied to the channel points the user earned
+type CommunityPointsMultiplier struct {
+	// ReasonCode is why the bonus was applied, e.g. "SUB_T1"
+	ReasonCode string  `json:"reason_code"`
+	Factor     float64 `json:"factor"`
+}
+
+// CommunityPointsEarned is sent when the user earns channel points
+type CommunityPointsEarned struct {
+	Timestamp time.Time              `json:"timestamp"`
+	ChannelID string                 `json:"channel_id"`
+	PointGain CommunityPointsGain    `json:"point_gain"`
+	Balance   CommunityPointsBalance `json:"balance"`
+
+	RawEvent
+}
+
+// CommunityPointsSpent is sent when the user spends channel points
+type CommunityPointsSpent struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Balance   CommunityPointsBalance `json:"balance"`
+
+	RawEvent
+}
+
+// CommunityPointsClaimAvailable is sent when a bonus channel points claim becomes available to the user
+type CommunityPointsClaimAvailable struct {
+	Timestamp time.Time `json:"timestamp"`
+	Claim     struct {
+		ID        string              `json:"id"`
+		UserID    string              `json:"user_id"`
+		ChannelID string              `json:"channel_id"`
+		PointGain CommunityPointsGain `json:"point_gain"`
+		CreatedAt time.Time           `json:"created_at"`
+	} `json:"claim"`
+
+	RawEvent
+}
+
+type outerCommunityPointsUserEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// parseCommunityPointsUserEvent parses any message sent on the community points user topic
+// The returned value is one of *CommunityPointsEarned, *CommunityPointsSpent or *CommunityPointsClaimAvailable
+// Returns nil without an error for the message types we don't handle, since this topic carries many of them
+func parseCommunityPointsUserEvent(bytes []byte) (interface{}, error) {
+	outer := &outerCommunityPointsUserEvent{}
+	err := json.Unmarshal(bytes, outer)
+	if err != nil {
+		return nil, err
+	}
+
+	var data RawDataEvent
+	switch outer.Type {
+	case communityPointsUserMessageTypePointsEarned:
+		data = &CommunityPointsEarned{}
+	case communityPointsUserMessageTypePointsSpent:
+		data = &CommunityPointsSpent{}
+	case communityPointsUserMessageTypeClaimAvailable:
+		data = &CommunityPointsClaimAvailable{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(outer.Data, data); err != nil {
+		return nil, err
+	}
+
+	raw := data.RawData()
+	*raw = newRawEvent(bytes, outer)
+	raw.addUnknownFields("data", outer.Data, data)
+
+	return data, nil
+}
+
+func parseUserIDFromCommunityPointsUserTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse user ID from community points user topic")
+	}
+
+	return parts[1], nil
+}
+
+func isCommunityPointsUserEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, communityPointsUserEventTopicPrefix)
+}
+
+// CommunityPointsUserEventTopic returns a properly formatted community points user event topic string with the given user ID argument
+func CommunityPointsUserEventTopic(userID string) string {
+	const f = `community-points-user-v1.%s`
+	return fmt.Sprintf(f, userID)
+}
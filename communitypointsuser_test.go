@@ -0,0 +1,133 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseCommunityPointsUserEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Points earned",
+			input:      `{"type":"MESSAGE","data":{"topic":"community-points-user-v1.133077169","message":"{\"type\":\"points-earned\",\"data\":{\"timestamp\":\"2023-06-17T15:04:31Z\",\"channel_id\":\"11148817\",\"point_gain\":{\"user_id\":\"133077169\",\"channel_id\":\"11148817\",\"total_points\":10,\"baseline_points\":10,\"reason_code\":\"WATCH\",\"multipliers\":[]},\"balance\":{\"user_id\":\"133077169\",\"channel_id\":\"11148817\",\"balance\":1234}}}"}}`,
+			isValidMsg: true,
+			expected: &CommunityPointsEarned{
+				Timestamp: time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+				ChannelID: "11148817",
+				PointGain: CommunityPointsGain{
+					UserID:         "133077169",
+					ChannelID:      "11148817",
+					TotalPoints:    10,
+					BaselinePoints: 10,
+					ReasonCode:     "WATCH",
+					Multipliers:    []CommunityPointsMultiplier{},
+				},
+				Balance: CommunityPointsBalance{
+					UserID:    "133077169",
+					ChannelID: "11148817",
+					Balance:   1234,
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Points spent",
+			input:      `{"type":"MESSAGE","data":{"topic":"community-points-user-v1.133077169","message":"{\"type\":\"points-spent\",\"data\":{\"timestamp\":\"2023-06-17T15:04:31Z\",\"balance\":{\"user_id\":\"133077169\",\"channel_id\":\"11148817\",\"balance\":234}}}"}}`,
+			isValidMsg: true,
+			expected: &CommunityPointsSpent{
+				Timestamp: time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+				Balance: CommunityPointsBalance{
+					UserID:    "133077169",
+					ChannelID: "11148817",
+					Balance:   234,
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"community-points-user-v1.133077169","message":"{\"type\":\"reward-redeemed\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"community-points-user-v1.133077169","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isCommunityPointsUserEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseCommunityPointsUserEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					if testCase.expected == nil {
+						c.Assert(actual, qt.IsNil)
+					} else {
+						c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+					}
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParseCommunityPointsClaimAvailable(t *testing.T) {
+	c := qt.New(t)
+
+	input := `{"type":"claim-available","data":{"timestamp":"2023-06-17T15:04:31Z","claim":{"id":"f0e1d2c3-4b0b-8b0b-6b4b-4b0b8b0b6b4b","user_id":"133077169","channel_id":"11148817","point_gain":{"user_id":"133077169","channel_id":"11148817","total_points":50,"baseline_points":50,"reason_code":"CLAIM","multipliers":[{"reason_code":"SUB_T1","factor":0.2}]},"created_at":"2023-06-17T15:04:30Z"}}}`
+
+	actual, err := parseCommunityPointsUserEvent([]byte(input))
+	c.Assert(err, qt.IsNil)
+
+	claim, ok := actual.(*CommunityPointsClaimAvailable)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(claim.Claim.ID, qt.Equals, "f0e1d2c3-4b0b-8b0b-6b4b-4b0b8b0b6b4b")
+	c.Assert(claim.Claim.ChannelID, qt.Equals, "11148817")
+	c.Assert(claim.Claim.PointGain.TotalPoints, qt.Equals, 50)
+	c.Assert(claim.Claim.PointGain.Multipliers, qt.HasLen, 1)
+	c.Assert(claim.Claim.PointGain.Multipliers[0].Factor, qt.Equals, 0.2)
+	c.Assert(claim.Claim.CreatedAt, qt.Equals, time.Date(2023, time.June, 17, 15, 4, 30, 0, time.UTC))
+}
+
+func TestParseCommunityPointsUserTopicUserID(t *testing.T) {
+	c := qt.New(t)
+
+	userID, err := parseUserIDFromCommunityPointsUserTopic(CommunityPointsUserEventTopic("123"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, "123")
+
+	_, err = parseUserIDFromCommunityPointsUserTopic("community-points-user-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse user ID from community points user topic")
+}
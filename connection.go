@@ -294,7 +294,7 @@ func (c *connection) parseMessage(b []byte) error {
 
 	switch getMessageType(msg.Data.Topic) {
 	case messageTypeModerationAction:
-		d, err := parseModerationAction(innerMessageBytes)
+		d, err := parseModerationMessage(innerMessageBytes)
 		if err != nil {
 			return err
 		}
@@ -334,6 +334,10 @@ func (c *connection) parseMessage(b []byte) error {
 		if err != nil {
 			return err
 		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
 		c.messageBus <- sharedMessage{
 			Topic:   msg.Data.Topic,
 			Message: d,
@@ -347,6 +351,195 @@ func (c *connection) parseMessage(b []byte) error {
 			Topic:   msg.Data.Topic,
 			Message: d,
 		}
+	case messageTypeBitsBadgeUnlockEvent:
+		d, err := parseBitsBadgeUnlockEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeLowTrustUserEvent:
+		d, err := parseLowTrustUserEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeUserModerationNotificationEvent:
+		d, err := parseUserModerationNotificationEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeRaidEvent:
+		d, err := parseRaidEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeMysteryGiftEvent:
+		d, err := parseMysteryGiftEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeLeaderboardUpdate:
+		d, err := parseLeaderboardUpdate(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeChatRoomUpdate:
+		d, err := parseChatRoomUpdate(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeBroadcastSettingsUpdate:
+		d, err := parseBroadcastSettingsUpdate(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeChatroomsUserModerationAction:
+		d, err := parseChatroomsUserModerationAction(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeShoutoutEvent:
+		d, err := parseShoutoutEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypePinnedChatEvent:
+		d, err := parsePinnedChatEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeUnbanRequestEvent:
+		d, err := parseUnbanRequestEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeCreatorGoalEvent:
+		d, err := parseCreatorGoalEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeCharityDonationEvent:
+		d, err := parseCharityDonationEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeUserSubscribeEvent:
+		d, err := parseUserSubscribeEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeCommunityPointsUserEvent:
+		d, err := parseCommunityPointsUserEvent(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			// Message type we don't handle
+			return nil
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
+	case messageTypeExtensionMessage:
+		d, err := parseExtensionMessage(innerMessageBytes)
+		if err != nil {
+			return err
+		}
+		c.messageBus <- sharedMessage{
+			Topic:   msg.Data.Topic,
+			Message: d,
+		}
 
 	default:
 		fallthrough
@@ -0,0 +1,138 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch creator goal events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const creatorGoalsEventTopicPrefix = "creator-goals-events-v1."
+
+// Known values of the outer message type on the creator goals topic
+const (
+	creatorGoalsMessageTypeCreated  = "goal_created"
+	creatorGoalsMessageTypeUpdated  = "goal_updated"
+	creatorGoalsMessageTypeAchieved = "goal_achieved"
+	creatorGoalsMessageTypeEnded    = "goal_ended"
+)
+
+// Known values of CreatorGoal.ContributionType
+const (
+	CreatorGoalContributionFollowers = "FOLLOWERS"
+	CreatorGoalContributionSubs      = "SUBS"
+	CreatorGoalContributionSubPoints = "SUB_POINTS"
+)
+
+// CreatorGoal describes a follower or subscription goal, shared by all creator goal events
+type CreatorGoal struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channelID"`
+
+	// ContributionType is one of the CreatorGoalContribution* constants
+	ContributionType string `json:"contributionType"`
+
+	// State is the goal's state, e.g. "STARTED" or "ENDED"
+	State string `json:"state"`
+
+	Description string `json:"description"`
+
+	CurrentContributions int `json:"currentContributions"`
+	TargetContributions  int `json:"targetContributions"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	// EndedAt is nil while the goal is active
+	EndedAt *time.Time `json:"endedAt"`
+}
+
+// Progress returns how far along the goal is, where 1 means the target has been reached
+func (g *CreatorGoal) Progress() float64 {
+	if g.TargetContributions == 0 {
+		return 0
+	}
+
+	return float64(g.CurrentContributions) / float64(g.TargetContributions)
+}
+
+// GoalCreated is sent when a creator goal is created
+type GoalCreated struct {
+	CreatorGoal
+
+	RawEvent
+}
+
+// GoalUpdated is sent when a creator goal's progress or settings change
+type GoalUpdated struct {
+	CreatorGoal
+
+	RawEvent
+}
+
+// GoalAchieved is sent when a creator goal reaches its target
+type GoalAchieved struct {
+	CreatorGoal
+
+	RawEvent
+}
+
+// GoalEnded is sent when a creator goal is ended
+type GoalEnded struct {
+	CreatorGoal
+
+	RawEvent
+}
+
+type outerCreatorGoalEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Goal CreatorGoal `json:"goal"`
+	} `json:"data"`
+}
+
+// parseCreatorGoalEvent parses any message sent on the creator goals topic
+// The returned value is one of *GoalCreated, *GoalUpdated, *GoalAchieved or *GoalEnded
+// Returns nil without an error for message types we don't handle
+func parseCreatorGoalEvent(bytes []byte) (interface{}, error) {
+	data := &outerCreatorGoalEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newRawEvent(bytes, data)
+
+	switch data.Type {
+	case creatorGoalsMessageTypeCreated:
+		return &GoalCreated{CreatorGoal: data.Data.Goal, RawEvent: raw}, nil
+	case creatorGoalsMessageTypeUpdated:
+		return &GoalUpdated{CreatorGoal: data.Data.Goal, RawEvent: raw}, nil
+	case creatorGoalsMessageTypeAchieved:
+		return &GoalAchieved{CreatorGoal: data.Data.Goal, RawEvent: raw}, nil
+	case creatorGoalsMessageTypeEnded:
+		return &GoalEnded{CreatorGoal: data.Data.Goal, RawEvent: raw}, nil
+	}
+
+	return nil, nil
+}
+
+func parseChannelIDFromCreatorGoalsTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from creator goals topic")
+	}
+
+	return parts[1], nil
+}
+
+func isCreatorGoalsEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, creatorGoalsEventTopicPrefix)
+}
+
+// CreatorGoalsEventTopic returns a properly formatted creator goals event topic string with the given channel ID argument
+func CreatorGoalsEventTopic(channelID string) string {
+	const f = `creator-goals-events-v1.%s`
+	return fmt.Sprintf(f, channelID)
+}
@@ -0,0 +1,127 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseCreatorGoalEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	goal := CreatorGoal{
+		ID:                   "1f5f6b1e-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+		ChannelID:            "11148817",
+		ContributionType:     CreatorGoalContributionFollowers,
+		State:                "STARTED",
+		Description:          "Follower goal",
+		CurrentContributions: 50,
+		TargetContributions:  100,
+		CreatedAt:            time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+	}
+
+	endedAt := time.Date(2023, time.June, 17, 18, 0, 0, 0, time.UTC)
+	endedGoal := goal
+	endedGoal.State = "ENDED"
+	endedGoal.EndedAt = &endedAt
+
+	testCases := []testCase{
+		{
+			label:            "Created",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{\"type\":\"goal_created\",\"data\":{\"goal\":{\"id\":\"1f5f6b1e-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channelID\":\"11148817\",\"contributionType\":\"FOLLOWERS\",\"state\":\"STARTED\",\"description\":\"Follower goal\",\"currentContributions\":50,\"targetContributions\":100,\"createdAt\":\"2023-06-17T15:04:31Z\",\"endedAt\":null}}}"}}`,
+			isValidMsg:       true,
+			expected:         &GoalCreated{CreatorGoal: goal},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Updated",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{\"type\":\"goal_updated\",\"data\":{\"goal\":{\"id\":\"1f5f6b1e-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channelID\":\"11148817\",\"contributionType\":\"FOLLOWERS\",\"state\":\"STARTED\",\"description\":\"Follower goal\",\"currentContributions\":50,\"targetContributions\":100,\"createdAt\":\"2023-06-17T15:04:31Z\",\"endedAt\":null}}}"}}`,
+			isValidMsg:       true,
+			expected:         &GoalUpdated{CreatorGoal: goal},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Achieved",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{\"type\":\"goal_achieved\",\"data\":{\"goal\":{\"id\":\"1f5f6b1e-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channelID\":\"11148817\",\"contributionType\":\"FOLLOWERS\",\"state\":\"STARTED\",\"description\":\"Follower goal\",\"currentContributions\":50,\"targetContributions\":100,\"createdAt\":\"2023-06-17T15:04:31Z\",\"endedAt\":null}}}"}}`,
+			isValidMsg:       true,
+			expected:         &GoalAchieved{CreatorGoal: goal},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Ended",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{\"type\":\"goal_ended\",\"data\":{\"goal\":{\"id\":\"1f5f6b1e-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channelID\":\"11148817\",\"contributionType\":\"FOLLOWERS\",\"state\":\"ENDED\",\"description\":\"Follower goal\",\"currentContributions\":50,\"targetContributions\":100,\"createdAt\":\"2023-06-17T15:04:31Z\",\"endedAt\":\"2023-06-17T18:00:00Z\"}}}"}}`,
+			isValidMsg:       true,
+			expected:         &GoalEnded{CreatorGoal: endedGoal},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{\"type\":\"forsen\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"creator-goals-events-v1.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isCreatorGoalsEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseCreatorGoalEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestCreatorGoalProgress(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert((&CreatorGoal{CurrentContributions: 50, TargetContributions: 100}).Progress(), qt.Equals, 0.5)
+	c.Assert((&CreatorGoal{CurrentContributions: 50}).Progress(), qt.Equals, 0.0)
+}
+
+func TestParseCreatorGoalsTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromCreatorGoalsTopic(CreatorGoalsEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromCreatorGoalsTopic("creator-goals-events-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from creator goals topic")
+}
@@ -0,0 +1,142 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch extension pubsub messages
+// Extension topics authenticate with a JWT signed with the extension secret, see SignExtensionJWT
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const extensionEventTopicPrefix = "channel-ext-v1."
+
+// Known values of ExtensionClaims.Role
+const (
+	ExtensionRoleExternal    = "external"
+	ExtensionRoleBroadcaster = "broadcaster"
+	ExtensionRoleModerator   = "moderator"
+	ExtensionRoleViewer      = "viewer"
+)
+
+// ExtensionPubSubPerms describes which extension targets a JWT may listen and send to, e.g. "broadcast", "global" or "whisper-<opaqueUserID>"
+type ExtensionPubSubPerms struct {
+	Listen []string `json:"listen,omitempty"`
+	Send   []string `json:"send,omitempty"`
+}
+
+// ExtensionClaims are the claims of an extension JWT
+type ExtensionClaims struct {
+	// ExpiresAt is a unix timestamp
+	ExpiresAt int64 `json:"exp"`
+
+	// Role is one of the ExtensionRole* constants
+	Role string `json:"role"`
+
+	ChannelID    string `json:"channel_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	OpaqueUserID string `json:"opaque_user_id,omitempty"`
+
+	PubSubPerms ExtensionPubSubPerms `json:"pubsub_perms"`
+}
+
+// NewExtensionClaims returns claims for the given role and channel that expire after the given duration
+// The claims may listen to the "broadcast" target, add whisper targets to PubSubPerms.Listen to listen to those too
+func NewExtensionClaims(role, channelID string, expiresIn time.Duration) *ExtensionClaims {
+	return &ExtensionClaims{
+		ExpiresAt: time.Now().Add(expiresIn).Unix(),
+		Role:      role,
+		ChannelID: channelID,
+		PubSubPerms: ExtensionPubSubPerms{
+			Listen: []string{"broadcast"},
+		},
+	}
+}
+
+// SignExtensionJWT signs the given claims with the extension secret, returning a JWT that can be passed as the authToken to Listen
+// secret is the base64 encoded extension secret shown in the extension's settings
+func SignExtensionJWT(secret string, claims *ExtensionClaims) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	const header = `{"alg":"HS256","typ":"JWT"}`
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ExtensionMessage describes a message sent by an extension, coming from Twitch's PubSub servers
+type ExtensionMessage struct {
+	// ExtensionID is the client ID of the extension that sent the message
+	ExtensionID string `json:"-"`
+
+	// Target is what the message was sent to, e.g. "broadcast" or "whisper-<opaqueUserID>"
+	Target string `json:"-"`
+
+	// ContentType is the content type the extension sent the message with, e.g. "application/json"
+	ContentType string `json:"content_type"`
+
+	// Content contains the messages sent by the extension
+	Content []string `json:"content"`
+
+	RawEvent
+}
+
+func parseExtensionMessage(bytes []byte) (*ExtensionMessage, error) {
+	data := &ExtensionMessage{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+// parseExtensionTopic parses a topic like channel-ext-v1.<channelID>-<extensionID>-<target>
+func parseExtensionTopic(topic string) (channelID, extensionID, target string, err error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", "", "", errors.New("unable to parse extension topic")
+	}
+
+	fields := strings.SplitN(parts[1], "-", 3)
+	if len(fields) != 3 {
+		return "", "", "", errors.New("unable to parse extension topic")
+	}
+
+	return fields[0], fields[1], fields[2], nil
+}
+
+func isExtensionEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, extensionEventTopicPrefix)
+}
+
+// ExtensionBroadcastTopic returns a properly formatted extension broadcast topic string with the given channel and extension ID arguments
+func ExtensionBroadcastTopic(channelID, extensionID string) string {
+	const f = `channel-ext-v1.%s-%s-broadcast`
+	return fmt.Sprintf(f, channelID, extensionID)
+}
+
+// ExtensionWhisperTopic returns a properly formatted extension whisper topic string with the given channel, extension and opaque user ID arguments
+func ExtensionWhisperTopic(channelID, extensionID, opaqueUserID string) string {
+	const f = `channel-ext-v1.%s-%s-whisper-%s`
+	return fmt.Sprintf(f, channelID, extensionID, opaqueUserID)
+}
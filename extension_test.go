@@ -0,0 +1,191 @@
+package twitchpubsub
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseExtensionMessage(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *ExtensionMessage
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Broadcast",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-ext-v1.11148817-uo6dggojyb8d6soh92zknwmi5ej1q2-broadcast","message":"{\"content\":[\"{\\\"votes\\\":3}\"],\"content_type\":\"application/json\"}"}}`,
+			isValidMsg: true,
+			expected: &ExtensionMessage{
+				ContentType: "application/json",
+				Content:     []string{`{"votes":3}`},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-ext-v1.11148817-uo6dggojyb8d6soh92zknwmi5ej1q2-broadcast","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Not an extension topic",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-bits-events-v2.11148817","message":"{}"}}`,
+			isValidMsg:       false,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isExtensionEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseExtensionMessage(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseExtensionTopic(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label               string
+		inputTopic          string
+		expectedChannelID   string
+		expectedExtensionID string
+		expectedTarget      string
+		expectedErr         error
+	}
+
+	testCases := []testCase{
+		{
+			label:               "Broadcast",
+			inputTopic:          ExtensionBroadcastTopic("456", "abc"),
+			expectedChannelID:   "456",
+			expectedExtensionID: "abc",
+			expectedTarget:      "broadcast",
+			expectedErr:         nil,
+		},
+		{
+			label:               "Whisper",
+			inputTopic:          ExtensionWhisperTopic("456", "abc", "U12345"),
+			expectedChannelID:   "456",
+			expectedExtensionID: "abc",
+			expectedTarget:      "whisper-U12345",
+			expectedErr:         nil,
+		},
+		{
+			label:       "Malformed",
+			inputTopic:  "channel-ext-v1.456",
+			expectedErr: errors.New("unable to parse extension topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			channelID, extensionID, target, err := parseExtensionTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(channelID, qt.Equals, testCase.expectedChannelID)
+			c.Assert(extensionID, qt.Equals, testCase.expectedExtensionID)
+			c.Assert(target, qt.Equals, testCase.expectedTarget)
+		})
+	}
+}
+
+func TestSignExtensionJWT(t *testing.T) {
+	c := qt.New(t)
+
+	key := []byte("not a real extension secret")
+	secret := base64.StdEncoding.EncodeToString(key)
+
+	claims := &ExtensionClaims{
+		ExpiresAt: 1600000000,
+		Role:      ExtensionRoleExternal,
+		ChannelID: "456",
+		PubSubPerms: ExtensionPubSubPerms{
+			Send: []string{"broadcast"},
+		},
+	}
+
+	token, err := SignExtensionJWT(secret, claims)
+	c.Assert(err, qt.IsNil)
+
+	parts := strings.Split(token, ".")
+	c.Assert(parts, qt.HasLen, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(header), qt.Equals, `{"alg":"HS256","typ":"JWT"}`)
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	c.Assert(err, qt.IsNil)
+	actualClaims := &ExtensionClaims{}
+	c.Assert(json.Unmarshal(payload, actualClaims), qt.IsNil)
+	c.Assert(actualClaims, qt.DeepEquals, claims)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	c.Assert(parts[2], qt.Equals, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+
+	_, err = SignExtensionJWT("not base64!", claims)
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestNewExtensionClaimsCanListen(t *testing.T) {
+	c := qt.New(t)
+
+	secret := base64.StdEncoding.EncodeToString([]byte("not a real extension secret"))
+
+	token, err := SignExtensionJWT(secret, NewExtensionClaims(ExtensionRoleExternal, "456", time.Hour))
+	c.Assert(err, qt.IsNil)
+
+	parts := strings.Split(token, ".")
+	c.Assert(parts, qt.HasLen, 3)
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	c.Assert(err, qt.IsNil)
+
+	actual := struct {
+		PubSubPerms struct {
+			Listen []string `json:"listen"`
+		} `json:"pubsub_perms"`
+	}{}
+	c.Assert(json.Unmarshal(payload, &actual), qt.IsNil)
+	c.Assert(actual.PubSubPerms.Listen, qt.DeepEquals, []string{"broadcast"})
+}
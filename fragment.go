@@ -0,0 +1,309 @@
+package twitchpubsub
+
+// Helper functions and structures for turning the different rich text payloads into a list of fragments
+// Twitch's emote offsets count unicode code points, not bytes, so all offsets are applied to the message as a []rune
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Known values of Fragment.Type
+const (
+	FragmentTypeText           = "text"
+	FragmentTypeEmote          = "emote"
+	FragmentTypeCheermote      = "cheermote"
+	FragmentTypeMention        = "mention"
+	FragmentTypeAutoModFlagged = "automod"
+)
+
+// Fragment describes one part of a chat message
+type Fragment struct {
+	// Type is one of the FragmentType* constants
+	Type string
+
+	// Text is the text of the fragment as it appeared in the message
+	Text string
+
+	// EmoteID is set for emote fragments
+	EmoteID string
+
+	// CheermotePrefix and Bits are set for cheermote fragments, e.g. "Cheer" and 100 for "Cheer100"
+	CheermotePrefix string
+	Bits            int
+
+	// MentionLogin is set for mention fragments, and is the mentioned login without the leading @
+	MentionLogin string
+
+	// AutoModTopics is set for automod flagged fragments, and maps each topic to the level it was flagged at
+	AutoModTopics map[string]int
+}
+
+// emoteRange is an emote spanning the code points Start through End, both inclusive
+type emoteRange struct {
+	ID    string
+	Start int
+	End   int
+}
+
+// fragmentsFromEmoteRanges splits text into emote fragments at the given ranges and text or mention fragments between them
+// Ranges that overlap a previous range or fall outside of the text are ignored
+func fragmentsFromEmoteRanges(text string, emotes []emoteRange) []Fragment {
+	runes := []rune(text)
+
+	sorted := make([]emoteRange, len(emotes))
+	copy(sorted, emotes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var fragments []Fragment
+	pos := 0
+	for _, emote := range sorted {
+		if emote.Start < pos || emote.End < emote.Start || emote.End >= len(runes) {
+			continue
+		}
+
+		fragments = append(fragments, splitWords(string(runes[pos:emote.Start]), mentionFragment)...)
+		fragments = append(fragments, Fragment{
+			Type:    FragmentTypeEmote,
+			Text:    string(runes[emote.Start : emote.End+1]),
+			EmoteID: emote.ID,
+		})
+		pos = emote.End + 1
+	}
+
+	return append(fragments, splitWords(string(runes[pos:]), mentionFragment)...)
+}
+
+// splitWords calls classify on each whitespace separated word of text
+// Words classify returns nil for are merged together with the surrounding whitespace into text fragments
+func splitWords(text string, classify func(word string) *Fragment) []Fragment {
+	var fragments []Fragment
+	var pending strings.Builder
+
+	flush := func() {
+		if pending.Len() > 0 {
+			fragments = append(fragments, Fragment{
+				Type: FragmentTypeText,
+				Text: pending.String(),
+			})
+			pending.Reset()
+		}
+	}
+
+	for len(text) > 0 {
+		end := strings.IndexFunc(text, unicode.IsSpace)
+		if end == 0 {
+			end = strings.IndexFunc(text, func(r rune) bool { return !unicode.IsSpace(r) })
+			if end == -1 {
+				end = len(text)
+			}
+			pending.WriteString(text[:end])
+			text = text[end:]
+			continue
+		}
+		if end == -1 {
+			end = len(text)
+		}
+
+		word := text[:end]
+		text = text[end:]
+
+		if fragment := classify(word); fragment != nil {
+			flush()
+			fragments = append(fragments, *fragment)
+		} else {
+			pending.WriteString(word)
+		}
+	}
+
+	flush()
+
+	return fragments
+}
+
+// mentionFragment returns a mention fragment if word is an @login mention
+func mentionFragment(word string) *Fragment {
+	if len(word) < 2 || word[0] != '@' {
+		return nil
+	}
+
+	login := word[1:]
+	for _, r := range login {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return nil
+		}
+	}
+
+	return &Fragment{
+		Type:         FragmentTypeMention,
+		Text:         word,
+		MentionLogin: strings.ToLower(login),
+	}
+}
+
+// cheermoteFragment returns a cheermote fragment if word is one of the given prefixes followed by an amount of bits, e.g. "Cheer100"
+// Prefixes are matched case insensitively
+func cheermoteFragment(word string, prefixes []string) *Fragment {
+	for _, prefix := range prefixes {
+		if len(word) <= len(prefix) || !strings.EqualFold(word[:len(prefix)], prefix) {
+			continue
+		}
+
+		bits := 0
+		valid := true
+		for _, r := range word[len(prefix):] {
+			if r < '0' || r > '9' {
+				valid = false
+				break
+			}
+			bits = bits*10 + int(r-'0')
+		}
+		if !valid || bits == 0 {
+			continue
+		}
+
+		return &Fragment{
+			Type:            FragmentTypeCheermote,
+			Text:            word,
+			CheermotePrefix: prefix,
+			Bits:            bits,
+		}
+	}
+
+	return nil
+}
+
+// contentFragments converts one fragment of a message that Twitch already split up
+// The fragment is an emote if emoteID is set, otherwise its text is split into text and mention fragments
+func contentFragments(text string, emoteID string) []Fragment {
+	if emoteID != "" {
+		return []Fragment{{
+			Type:    FragmentTypeEmote,
+			Text:    text,
+			EmoteID: emoteID,
+		}}
+	}
+
+	return splitWords(text, mentionFragment)
+}
+
+// Fragments splits the sub message into text, emote and mention fragments
+func (m *SubMessage) Fragments() []Fragment {
+	emotes := make([]emoteRange, 0, len(m.Emotes))
+	for _, emote := range m.Emotes {
+		emotes = append(emotes, emoteRange{
+			ID:    emote.ID,
+			Start: emote.Start,
+			End:   emote.End,
+		})
+	}
+
+	return fragmentsFromEmoteRanges(m.Message, emotes)
+}
+
+// Fragments splits the whisper body into text, emote and mention fragments
+// Emotes in Tags.Emotes that are not objects with an emote ID, start and end are ignored
+func (e *WhisperEvent) Fragments() []Fragment {
+	emotes := make([]emoteRange, 0, len(e.Tags.Emotes))
+	for _, v := range e.Tags.Emotes {
+		emote, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, ok := emote["emote_id"].(string)
+		if !ok {
+			continue
+		}
+		start, ok := emote["start"].(float64)
+		if !ok {
+			continue
+		}
+		end, ok := emote["end"].(float64)
+		if !ok {
+			continue
+		}
+
+		emotes = append(emotes, emoteRange{
+			ID:    id,
+			Start: int(start),
+			End:   int(end),
+		})
+	}
+
+	return fragmentsFromEmoteRanges(e.Body, emotes)
+}
+
+// Fragments converts the message's fragments, marking the ones AutoMod flagged
+func (e *AutoModQueueEvent) Fragments() []Fragment {
+	var fragments []Fragment
+	for _, f := range e.Message.Content.Fragments {
+		if f.Automod.Topics.Flagged() {
+			topics := make(map[string]int, len(f.Automod.Topics.Levels))
+			for topic, level := range f.Automod.Topics.Levels {
+				topics[topic] = level
+			}
+
+			fragments = append(fragments, Fragment{
+				Type:          FragmentTypeAutoModFlagged,
+				Text:          f.Text,
+				AutoModTopics: topics,
+			})
+			continue
+		}
+
+		emoteID := ""
+		if f.Emoticon != nil {
+			emoteID = f.Emoticon.ID
+		}
+
+		fragments = append(fragments, contentFragments(f.Text, emoteID)...)
+	}
+
+	return fragments
+}
+
+// Fragments converts the message's fragments into text, emote and mention fragments
+func (m *LowTrustUserMessage) Fragments() []Fragment {
+	var fragments []Fragment
+	for _, f := range m.MessageContent.Fragments {
+		emoteID := ""
+		if f.Emoticon != nil {
+			emoteID = f.Emoticon.EmoticonID
+		}
+
+		fragments = append(fragments, contentFragments(f.Text, emoteID)...)
+	}
+
+	return fragments
+}
+
+// pinnedChatFragments converts the fragments of a pinned message into text, emote and mention fragments
+func pinnedChatFragments(in []pinnedChatFragment) []Fragment {
+	var fragments []Fragment
+	for _, f := range in {
+		emoteID := ""
+		if f.Emote != nil {
+			emoteID = f.Emote.ID
+		}
+
+		fragments = append(fragments, contentFragments(f.Text, emoteID)...)
+	}
+
+	return fragments
+}
+
+// Fragments splits the chat message into text, mention and cheermote fragments
+// Words made up of one of the given cheermote prefixes followed by an amount, e.g. "Cheer100", become cheermote fragments
+func (e *BitsEvent) Fragments(cheermotePrefixes []string) []Fragment {
+	return splitWords(e.ChatMessage, func(word string) *Fragment {
+		if fragment := cheermoteFragment(word, cheermotePrefixes); fragment != nil {
+			return fragment
+		}
+
+		return mentionFragment(word)
+	})
+}
@@ -0,0 +1,148 @@
+package twitchpubsub
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSubMessageFragments(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label    string
+		input    SubMessage
+		expected []Fragment
+	}
+
+	testCases := []testCase{
+		{
+			label: "No emotes",
+			input: SubMessage{
+				Message: "pajaCheese hi @pajlada",
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeText, Text: "pajaCheese hi "},
+				{Type: FragmentTypeMention, Text: "@pajlada", MentionLogin: "pajlada"},
+			},
+		},
+		{
+			label: "Emotes after multi-byte characters",
+			input: SubMessage{
+				Message: "héllo 😀 Kappa forsenE",
+				Emotes: []Emotes{
+					{Start: 14, End: 20, ID: "forsenE"},
+					{Start: 8, End: 12, ID: "25"},
+				},
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeText, Text: "héllo 😀 "},
+				{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+				{Type: FragmentTypeText, Text: " "},
+				{Type: FragmentTypeEmote, Text: "forsenE", EmoteID: "forsenE"},
+			},
+		},
+		{
+			label: "Out of range and overlapping emotes are ignored",
+			input: SubMessage{
+				Message: "Kappa",
+				Emotes: []Emotes{
+					{Start: 0, End: 4, ID: "25"},
+					{Start: 2, End: 4, ID: "overlap"},
+					{Start: 3, End: 10, ID: "outside"},
+				},
+			},
+			expected: []Fragment{
+				{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+			},
+		},
+		{
+			label:    "Empty message",
+			input:    SubMessage{},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			c.Assert(testCase.input.Fragments(), qt.DeepEquals, testCase.expected)
+		})
+	}
+}
+
+func TestWhisperEventFragments(t *testing.T) {
+	c := qt.New(t)
+
+	event := &WhisperEvent{
+		Body: "ä Kappa  @Forsen",
+	}
+	event.Tags.Emotes = []interface{}{
+		map[string]interface{}{"emote_id": "25", "start": float64(2), "end": float64(6)},
+		"garbage",
+	}
+
+	c.Assert(event.Fragments(), qt.DeepEquals, []Fragment{
+		{Type: FragmentTypeText, Text: "ä "},
+		{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+		{Type: FragmentTypeText, Text: "  "},
+		{Type: FragmentTypeMention, Text: "@Forsen", MentionLogin: "forsen"},
+	})
+}
+
+func TestAutoModQueueEventFragments(t *testing.T) {
+	c := qt.New(t)
+
+	event, err := parseAutoModQueueEvent([]byte(`{"type":"automod_caught_message","data":{"message":{"id":"1","content":{"text":"you are a fuck Kappa","fragments":[{"text":"you are a "},{"text":"fuck","automod":{"topics":{"swearing":4}}},{"text":" "},{"text":"Kappa","emoticon":{"emoticonID":"25"}}]}}}}`))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(event.Fragments(), qt.DeepEquals, []Fragment{
+		{Type: FragmentTypeText, Text: "you are a "},
+		{Type: FragmentTypeAutoModFlagged, Text: "fuck", AutoModTopics: map[string]int{"swearing": 4}},
+		{Type: FragmentTypeText, Text: " "},
+		{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+	})
+}
+
+func TestAutoModQueueEventFragmentsOtherTopics(t *testing.T) {
+	c := qt.New(t)
+
+	event, err := parseAutoModQueueEvent([]byte(`{"type":"automod_caught_message","data":{"message":{"id":"1","content":{"text":"you are stupid","fragments":[{"text":"you are "},{"text":"stupid","automod":{"topics":{"aggressive":2,"bullying":3,"identity":0}}}]}}}}`))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(event.Message.Content.Fragments[1].Automod.Topics.Swearing, qt.Equals, 0)
+	c.Assert(event.Fragments(), qt.DeepEquals, []Fragment{
+		{Type: FragmentTypeText, Text: "you are "},
+		{Type: FragmentTypeAutoModFlagged, Text: "stupid", AutoModTopics: map[string]int{"aggressive": 2, "bullying": 3, "identity": 0}},
+	})
+}
+
+func TestLowTrustUserMessageFragments(t *testing.T) {
+	c := qt.New(t)
+
+	event, err := parseLowTrustUserEvent([]byte(`{"type":"low_trust_user_new_message","data":{"low_trust_user":{"low_trust_id":"MTE3MjMxNjN8MTE0ODg4MTc=","channel_id":"11148817"},"message_content":{"text":"hello @pajlada Kappa","fragments":[{"text":"hello @pajlada "},{"text":"Kappa","emoticon":{"emoticonID":"25","emoticonSetID":"0"}}]},"message_id":"5f0f5a5e-3b1c-4e7b-8b0b-6b4b4b0b8b0b"}}`))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(event.NewMessage.Fragments(), qt.DeepEquals, []Fragment{
+		{Type: FragmentTypeText, Text: "hello "},
+		{Type: FragmentTypeMention, Text: "@pajlada", MentionLogin: "pajlada"},
+		{Type: FragmentTypeText, Text: " "},
+		{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+	})
+}
+
+func TestBitsEventFragments(t *testing.T) {
+	c := qt.New(t)
+
+	event := &BitsEvent{
+		ChatMessage: "cheer100 hello @pajlada Kappa50 Kappa PogChamp1 Cheerio",
+	}
+
+	c.Assert(event.Fragments([]string{"Cheer", "PogChamp"}), qt.DeepEquals, []Fragment{
+		{Type: FragmentTypeCheermote, Text: "cheer100", CheermotePrefix: "Cheer", Bits: 100},
+		{Type: FragmentTypeText, Text: " hello "},
+		{Type: FragmentTypeMention, Text: "@pajlada", MentionLogin: "pajlada"},
+		{Type: FragmentTypeText, Text: " Kappa50 Kappa "},
+		{Type: FragmentTypeCheermote, Text: "PogChamp1", CheermotePrefix: "PogChamp", Bits: 1},
+		{Type: FragmentTypeText, Text: " Cheerio"},
+	})
+}
@@ -0,0 +1,155 @@
+package twitchpubsub
+
+// Aggregation of gift subscriptions coming from the subscribe topic into gift batches
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultGiftBatchWindow is a sensible window to pass to Client.OnGiftBatch
+const DefaultGiftBatchWindow = 2 * time.Second
+
+// GiftBatchUser describes a user taking part in a gift batch
+type GiftBatchUser struct {
+	UserID      string
+	UserName    string
+	DisplayName string
+}
+
+// GiftBatchRecipient describes a user who received a gift subscription as part of a gift batch
+type GiftBatchRecipient struct {
+	GiftBatchUser
+
+	// MultiMonthDuration is the number of months the subscription was gifted for
+	MultiMonthDuration int
+}
+
+// GiftBatch describes a number of gift subscriptions sent by the same gifter within a short window
+type GiftBatch struct {
+	// ChannelID is the channel the subscriptions were gifted in
+	ChannelID string
+
+	// Gifter is the user who gifted the subscriptions
+	// Empty if IsAnonymous is true
+	Gifter GiftBatchUser
+
+	// IsAnonymous is true if the subscriptions were gifted anonymously
+	IsAnonymous bool
+
+	// Recipients are the users who received a gift subscription, in the order they were received
+	Recipients []GiftBatchRecipient
+
+	// SubPlan is the subscription plan ID of the gifted subscriptions (e.g. 1000, 2000, 3000)
+	SubPlan string
+
+	// Count is the number of gift subscriptions in this batch
+	Count int
+
+	// Time of the first gift subscription in this batch
+	Time time.Time
+}
+
+type giftBatchKey struct {
+	channelID string
+	gifterID  string
+	subPlan   string
+}
+
+type pendingGiftBatch struct {
+	batch *GiftBatch
+	timer *time.Timer
+}
+
+// giftBatchAggregator groups gift subscriptions from the same gifter into a GiftBatch
+// A batch is emitted once no new gift subscription from the same gifter has been seen for the duration of the window
+type giftBatchAggregator struct {
+	window time.Duration
+	emit   func(batch *GiftBatch)
+
+	mutex   sync.Mutex
+	pending map[giftBatchKey]*pendingGiftBatch
+}
+
+func newGiftBatchAggregator(window time.Duration, emit func(batch *GiftBatch)) *giftBatchAggregator {
+	return &giftBatchAggregator{
+		window:  window,
+		emit:    emit,
+		pending: make(map[giftBatchKey]*pendingGiftBatch),
+	}
+}
+
+func isGiftSubscription(event *SubscribeEvent) bool {
+	return event.Context == SubscribeContextSubGift || event.Context == SubscribeContextAnonSubGift
+}
+
+// add adds the given subscribe event to its gift batch
+// Returns false if the event is not a gift subscription
+func (a *giftBatchAggregator) add(event *SubscribeEvent) bool {
+	if !isGiftSubscription(event) {
+		return false
+	}
+
+	key := giftBatchKey{
+		channelID: event.ChannelID,
+		gifterID:  event.UserID,
+		subPlan:   event.SubPlan,
+	}
+
+	recipient := GiftBatchRecipient{
+		GiftBatchUser: GiftBatchUser{
+			UserID:      event.RecipientID,
+			UserName:    event.RecipientUserName,
+			DisplayName: event.RecipientDisplayName,
+		},
+		MultiMonthDuration: event.MultiMonthDuration,
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// If Stop fails, the timer already fired and its flush is waiting for the mutex to emit the batch
+	// The gift then starts a new batch, since re-arming the timer would make it flush the new batch early
+	if p, ok := a.pending[key]; ok && p.timer.Stop() {
+		p.batch.Recipients = append(p.batch.Recipients, recipient)
+		p.batch.Count++
+		p.timer.Reset(a.window)
+		return true
+	}
+
+	batch := &GiftBatch{
+		ChannelID: event.ChannelID,
+		Gifter: GiftBatchUser{
+			UserID:      event.UserID,
+			UserName:    event.UserName,
+			DisplayName: event.DisplayName,
+		},
+		IsAnonymous: event.Context == SubscribeContextAnonSubGift,
+		Recipients:  []GiftBatchRecipient{recipient},
+		SubPlan:     event.SubPlan,
+		Count:       1,
+		Time:        event.Time,
+	}
+
+	p := &pendingGiftBatch{
+		batch: batch,
+	}
+	p.timer = time.AfterFunc(a.window, func() {
+		a.flush(key, p)
+	})
+	a.pending[key] = p
+
+	return true
+}
+
+// flush emits the given batch, which is called exactly once per batch by its timer
+// The batch is only removed from pending if it hasn't already been replaced by a newer batch for the same key
+func (a *giftBatchAggregator) flush(key giftBatchKey, p *pendingGiftBatch) {
+	a.mutex.Lock()
+	if a.pending[key] == p {
+		delete(a.pending, key)
+	}
+	a.mutex.Unlock()
+
+	a.emit(p.batch)
+}
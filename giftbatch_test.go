@@ -0,0 +1,159 @@
+package twitchpubsub
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGiftBatchAggregator(t *testing.T) {
+	c := qt.New(t)
+
+	batches := make(chan *GiftBatch, 10)
+	aggregator := newGiftBatchAggregator(50*time.Millisecond, func(batch *GiftBatch) {
+		batches <- batch
+	})
+
+	gift := func(gifterID, recipientID string) *SubscribeEvent {
+		return &SubscribeEvent{
+			ChannelID:          "11148817",
+			UserID:             gifterID,
+			UserName:           "gifter" + gifterID,
+			DisplayName:        "Gifter" + gifterID,
+			RecipientID:        recipientID,
+			RecipientUserName:  "recipient" + recipientID,
+			SubPlan:            "1000",
+			Context:            SubscribeContextSubGift,
+			IsGift:             true,
+			MultiMonthDuration: 1,
+		}
+	}
+
+	c.Assert(aggregator.add(&SubscribeEvent{Context: SubscribeContextResub}), qt.IsFalse)
+
+	c.Assert(aggregator.add(gift("1", "10")), qt.IsTrue)
+	c.Assert(aggregator.add(gift("1", "11")), qt.IsTrue)
+	c.Assert(aggregator.add(gift("2", "20")), qt.IsTrue)
+	c.Assert(aggregator.add(gift("1", "12")), qt.IsTrue)
+
+	received := map[string]*GiftBatch{}
+	for i := 0; i < 2; i++ {
+		select {
+		case batch := <-batches:
+			received[batch.Gifter.UserID] = batch
+		case <-time.After(time.Second):
+			c.Fatal("timed out waiting for gift batch")
+		}
+	}
+
+	c.Assert(received["1"].Count, qt.Equals, 3)
+	c.Assert(received["1"].SubPlan, qt.Equals, "1000")
+	c.Assert(received["1"].IsAnonymous, qt.IsFalse)
+	c.Assert(received["1"].Gifter, qt.DeepEquals, GiftBatchUser{
+		UserID:      "1",
+		UserName:    "gifter1",
+		DisplayName: "Gifter1",
+	})
+	c.Assert(received["1"].Recipients, qt.HasLen, 3)
+	c.Assert(received["1"].Recipients[2].UserID, qt.Equals, "12")
+	c.Assert(received["1"].Recipients[2].MultiMonthDuration, qt.Equals, 1)
+
+	c.Assert(received["2"].Count, qt.Equals, 1)
+
+	select {
+	case batch := <-batches:
+		c.Fatalf("unexpected gift batch: %#v", batch)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestGiftBatchAggregatorAnonymous(t *testing.T) {
+	c := qt.New(t)
+
+	batches := make(chan *GiftBatch, 1)
+	aggregator := newGiftBatchAggregator(10*time.Millisecond, func(batch *GiftBatch) {
+		batches <- batch
+	})
+
+	c.Assert(aggregator.add(&SubscribeEvent{
+		ChannelID:   "11148817",
+		RecipientID: "72902587",
+		SubPlan:     "1000",
+		Context:     SubscribeContextAnonSubGift,
+		IsGift:      true,
+	}), qt.IsTrue)
+
+	select {
+	case batch := <-batches:
+		c.Assert(batch.IsAnonymous, qt.IsTrue)
+		c.Assert(batch.Gifter, qt.DeepEquals, GiftBatchUser{})
+		c.Assert(batch.Count, qt.Equals, 1)
+	case <-time.After(time.Second):
+		c.Fatal("timed out waiting for gift batch")
+	}
+}
+
+func TestGiftBatchAggregatorTimerAlreadyFired(t *testing.T) {
+	c := qt.New(t)
+
+	batches := make(chan *GiftBatch, 10)
+	aggregator := newGiftBatchAggregator(50*time.Millisecond, func(batch *GiftBatch) {
+		batches <- batch
+	})
+
+	gift := func(recipientID string) *SubscribeEvent {
+		return &SubscribeEvent{
+			ChannelID:   "11148817",
+			UserID:      "1",
+			RecipientID: recipientID,
+			SubPlan:     "1000",
+			Context:     SubscribeContextSubGift,
+			IsGift:      true,
+		}
+	}
+
+	c.Assert(aggregator.add(gift("10")), qt.IsTrue)
+
+	key := giftBatchKey{channelID: "11148817", gifterID: "1", subPlan: "1000"}
+
+	// Simulate the timer having fired, with its flush still waiting for the mutex
+	aggregator.mutex.Lock()
+	first := aggregator.pending[key]
+	c.Assert(first.timer.Stop(), qt.IsTrue)
+	aggregator.mutex.Unlock()
+
+	// The new gift can't extend the batch that is about to be emitted, so it starts a new one
+	c.Assert(aggregator.add(gift("11")), qt.IsTrue)
+
+	aggregator.flush(key, first)
+
+	select {
+	case batch := <-batches:
+		c.Assert(batch.Count, qt.Equals, 1)
+		c.Assert(batch.Recipients[0].UserID, qt.Equals, "10")
+	case <-time.After(time.Second):
+		c.Fatal("timed out waiting for gift batch")
+	}
+
+	// The stale flush must not have removed or emitted the newer batch
+	aggregator.mutex.Lock()
+	second, ok := aggregator.pending[key]
+	aggregator.mutex.Unlock()
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(second, qt.Not(qt.Equals), first)
+
+	select {
+	case batch := <-batches:
+		c.Assert(batch.Count, qt.Equals, 1)
+		c.Assert(batch.Recipients[0].UserID, qt.Equals, "11")
+	case <-time.After(time.Second):
+		c.Fatal("timed out waiting for gift batch")
+	}
+
+	select {
+	case batch := <-batches:
+		c.Fatalf("unexpected extra gift batch: %+v", batch)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
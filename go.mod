@@ -4,13 +4,13 @@ go 1.20
 
 require (
 	github.com/frankban/quicktest v1.14.6
+	github.com/google/go-cmp v0.6.0
 	github.com/gorilla/websocket v1.5.3
 	honnef.co/go/tools v0.4.7
 )
 
 require (
 	github.com/BurntSushi/toml v1.4.0 // indirect
-	github.com/google/go-cmp v0.6.0 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
@@ -1,6 +1,14 @@
 package twitchpubsub
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// deepEqualsIgnoringRaw compares parsed events without their RawEvent, which is covered by raw_test.go
+var deepEqualsIgnoringRaw = qt.CmpEquals(cmpopts.IgnoreTypes(RawEvent{}))
 
 type outerMessage struct {
 	Data struct {
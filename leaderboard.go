@@ -0,0 +1,114 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch leaderboard events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const leaderboardEventTopicPrefix = "leaderboard-events-v1."
+
+// Known leaderboard domains, found in LeaderboardUpdate.Identifier.Domain
+const (
+	LeaderboardDomainBits     = "bits-usage-by-channel-v1"
+	LeaderboardDomainSubGifts = "sub-gift-sent"
+)
+
+// LeaderboardPeriod is the time period a leaderboard covers
+type LeaderboardPeriod string
+
+// Known leaderboard periods
+const (
+	LeaderboardPeriodWeek    LeaderboardPeriod = "WEEK"
+	LeaderboardPeriodMonth   LeaderboardPeriod = "MONTH"
+	LeaderboardPeriodAllTime LeaderboardPeriod = "ALLTIME"
+)
+
+// LeaderboardEntry is a single entry on a leaderboard
+type LeaderboardEntry struct {
+	Rank  int `json:"rank"`
+	Score int `json:"score"`
+	// EntryKey is the ID of the user this entry belongs to
+	EntryKey string `json:"entry_key"`
+}
+
+// LeaderboardUpdate describes an update to a bits or sub gift leaderboard, coming from Twitch's PubSub servers
+type LeaderboardUpdate struct {
+	Identifier struct {
+		// Domain is either LeaderboardDomainBits or LeaderboardDomainSubGifts
+		Domain string `json:"domain"`
+		// GroupingKey is the channel ID the leaderboard belongs to
+		GroupingKey     string            `json:"grouping_key"`
+		TimeAggregation LeaderboardPeriod `json:"time_aggregation"`
+		// StartTime & EndTime are unix timestamps describing the period this leaderboard covers
+		StartTime int64 `json:"start_time"`
+		EndTime   int64 `json:"end_time"`
+	} `json:"identifier"`
+
+	// Top contains the top entries of the leaderboard, ordered by rank
+	Top []LeaderboardEntry `json:"top"`
+
+	// Context describes the entry that caused this update, and its neighbours on the leaderboard
+	Context struct {
+		Entry   LeaderboardEntry   `json:"entry"`
+		Context []LeaderboardEntry `json:"context"`
+	} `json:"context"`
+
+	RawEvent
+}
+
+func parseLeaderboardUpdate(bytes []byte) (*LeaderboardUpdate, error) {
+	data := &LeaderboardUpdate{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+// parseChannelIDFromLeaderboardTopic parses the channel ID from a topic like leaderboard-events-v1.bits-usage-by-channel-v1-<channelID>-<period>
+func parseChannelIDFromLeaderboardTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from leaderboard topic")
+	}
+
+	var rest string
+	switch {
+	case strings.HasPrefix(parts[1], LeaderboardDomainBits+"-"):
+		rest = strings.TrimPrefix(parts[1], LeaderboardDomainBits+"-")
+	case strings.HasPrefix(parts[1], LeaderboardDomainSubGifts+"-"):
+		rest = strings.TrimPrefix(parts[1], LeaderboardDomainSubGifts+"-")
+	default:
+		return "", errors.New("unable to parse channel ID from leaderboard topic")
+	}
+
+	i := strings.LastIndex(rest, "-")
+	if i == -1 {
+		return "", errors.New("unable to parse channel ID from leaderboard topic")
+	}
+
+	return rest[:i], nil
+}
+
+func isLeaderboardEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, leaderboardEventTopicPrefix)
+}
+
+// BitsLeaderboardEventTopic returns a properly formatted bits leaderboard event topic string with the given channel ID and period arguments
+func BitsLeaderboardEventTopic(channelID string, period LeaderboardPeriod) string {
+	const f = `leaderboard-events-v1.bits-usage-by-channel-v1-%s-%s`
+	return fmt.Sprintf(f, channelID, period)
+}
+
+// SubGiftLeaderboardEventTopic returns a properly formatted sub gift leaderboard event topic string with the given channel ID and period arguments
+func SubGiftLeaderboardEventTopic(channelID string, period LeaderboardPeriod) string {
+	const f = `leaderboard-events-v1.sub-gift-sent-%s-%s`
+	return fmt.Sprintf(f, channelID, period)
+}
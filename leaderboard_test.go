@@ -0,0 +1,105 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseLeaderboardUpdate(t *testing.T) {
+	c := qt.New(t)
+
+	input := `{"type":"MESSAGE","data":{"topic":"leaderboard-events-v1.bits-usage-by-channel-v1-11148817-WEEK","message":"{\"identifier\":{\"domain\":\"bits-usage-by-channel-v1\",\"grouping_key\":\"11148817\",\"time_aggregation\":\"WEEK\",\"start_time\":1686528000,\"end_time\":1687132800},\"top\":[{\"rank\":1,\"score\":1000,\"entry_key\":\"133077169\"},{\"rank\":2,\"score\":250,\"entry_key\":\"165495734\"}],\"context\":{\"entry\":{\"rank\":2,\"score\":250,\"entry_key\":\"165495734\"},\"context\":[{\"rank\":1,\"score\":1000,\"entry_key\":\"133077169\"},{\"rank\":2,\"score\":250,\"entry_key\":\"165495734\"}]}}"}}`
+
+	outerMessage, err := parseOuterMessage([]byte(input))
+	c.Assert(err, qt.IsNil)
+	c.Assert(isLeaderboardEventTopic(outerMessage.Data.Topic), qt.IsTrue)
+
+	actual, err := parseLeaderboardUpdate([]byte(outerMessage.Data.Message))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(actual.Identifier.Domain, qt.Equals, LeaderboardDomainBits)
+	c.Assert(actual.Identifier.GroupingKey, qt.Equals, "11148817")
+	c.Assert(actual.Identifier.TimeAggregation, qt.Equals, LeaderboardPeriodWeek)
+	c.Assert(actual.Identifier.StartTime, qt.Equals, int64(1686528000))
+	c.Assert(actual.Identifier.EndTime, qt.Equals, int64(1687132800))
+	c.Assert(actual.Top, qt.DeepEquals, []LeaderboardEntry{
+		{Rank: 1, Score: 1000, EntryKey: "133077169"},
+		{Rank: 2, Score: 250, EntryKey: "165495734"},
+	})
+	c.Assert(actual.Context.Entry, qt.DeepEquals, LeaderboardEntry{Rank: 2, Score: 250, EntryKey: "165495734"})
+
+	_, err = parseLeaderboardUpdate([]byte(`{forsen}`))
+	c.Assert(err, qt.ErrorMatches, "invalid character 'f' looking for beginning of object key string")
+}
+
+func TestCreateLeaderboardTopic(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(BitsLeaderboardEventTopic("456", LeaderboardPeriodWeek), qt.Equals, "leaderboard-events-v1.bits-usage-by-channel-v1-456-WEEK")
+	c.Assert(BitsLeaderboardEventTopic("456", LeaderboardPeriodAllTime), qt.Equals, "leaderboard-events-v1.bits-usage-by-channel-v1-456-ALLTIME")
+	c.Assert(SubGiftLeaderboardEventTopic("456", LeaderboardPeriodMonth), qt.Equals, "leaderboard-events-v1.sub-gift-sent-456-MONTH")
+}
+
+func TestParseLeaderboardTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label             string
+		inputTopic        string
+		expectedChannelID string
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			label:             "Bits",
+			inputTopic:        BitsLeaderboardEventTopic("456", LeaderboardPeriodWeek),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Sub gifts",
+			inputTopic:        SubGiftLeaderboardEventTopic("456", LeaderboardPeriodAllTime),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Sub gifts literal topic",
+			inputTopic:        "leaderboard-events-v1.sub-gift-sent-11148817-WEEK",
+			expectedChannelID: "11148817",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Unknown domain",
+			inputTopic:        "leaderboard-events-v1.forsen-456-WEEK",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from leaderboard topic"),
+		},
+		{
+			label:             "Missing period",
+			inputTopic:        "leaderboard-events-v1.sub-gift-sent-456",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from leaderboard topic"),
+		},
+		{
+			label:             "Malformed",
+			inputTopic:        "leaderboard-events-v1",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from leaderboard topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actualChannelID, err := parseChannelIDFromLeaderboardTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(actualChannelID, qt.Equals, testCase.expectedChannelID)
+		})
+	}
+}
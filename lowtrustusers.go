@@ -0,0 +1,176 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch low trust (suspicious) user events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const lowTrustUsersEventTopicPrefix = "low-trust-users."
+
+// Known values of LowTrustUserEvent.Type
+const (
+	LowTrustUserTreatmentUpdate = "low_trust_user_treatment_update"
+	LowTrustUserNewMessage      = "low_trust_user_new_message"
+)
+
+// Known values of LowTrustUser.Treatment
+const (
+	LowTrustUserTreatmentNoTreatment      = "NO_TREATMENT"
+	LowTrustUserTreatmentActiveMonitoring = "ACTIVE_MONITORING"
+	LowTrustUserTreatmentRestricted       = "RESTRICTED"
+)
+
+// Known values of LowTrustUser.BanEvasionEvaluation
+const (
+	BanEvasionEvaluationUnlikelyEvader = "UNLIKELY_EVADER"
+	BanEvasionEvaluationPossibleEvader = "POSSIBLE_EVADER"
+	BanEvasionEvaluationLikelyEvader   = "LIKELY_EVADER"
+	BanEvasionEvaluationUnknownEvader  = "UNKNOWN_EVADER"
+)
+
+// LowTrustUserEvent describes an incoming "Low Trust Users" event coming from Twitch's PubSub servers
+// Exactly one of TreatmentUpdate and NewMessage is set, depending on Type
+type LowTrustUserEvent struct {
+	// Type is either LowTrustUserTreatmentUpdate or LowTrustUserNewMessage
+	Type string
+
+	// TreatmentUpdate is set if a moderator (or Twitch) changed how a suspicious user is treated
+	TreatmentUpdate *LowTrustUser
+
+	// NewMessage is set if a restricted or monitored user sent a message
+	NewMessage *LowTrustUserMessage
+
+	RawEvent
+}
+
+// LowTrustUser describes how a suspicious user is treated in a channel
+type LowTrustUser struct {
+	// ID is the user ID of the suspicious user, and is only sent in new messages
+	ID         string `json:"id"`
+	LowTrustID string `json:"low_trust_id"`
+	ChannelID  string `json:"channel_id"`
+
+	// TargetUserID & TargetUser are only sent in treatment updates
+	TargetUserID string `json:"target_user_id"`
+	// TargetUser is the login name of the suspicious user
+	TargetUser string `json:"target_user"`
+
+	// Sender is only sent in new messages
+	Sender *LowTrustUserSender `json:"sender"`
+
+	// Treatment is one of the LowTrustUserTreatment* constants
+	Treatment string `json:"treatment"`
+
+	// Types describes why the user is considered suspicious (e.g. MANUALLY_ADDED, BANNED_IN_SHARED_CHANNEL)
+	Types []string `json:"types"`
+
+	// BanEvasionEvaluation is one of the BanEvasionEvaluation* constants
+	BanEvasionEvaluation string    `json:"ban_evasion_evaluation"`
+	EvaluatedAt          time.Time `json:"evaluated_at"`
+
+	// SharedBanChannelIDs contains the IDs of the channels the user is banned in that share their ban list with this channel
+	SharedBanChannelIDs []string `json:"shared_ban_channel_ids"`
+
+	UpdatedAt time.Time           `json:"updated_at"`
+	UpdatedBy LowTrustUserUpdater `json:"updated_by"`
+}
+
+// LowTrustUserUpdater describes the moderator who last changed a suspicious user's treatment
+type LowTrustUserUpdater struct {
+	ID          string `json:"id"`
+	Login       string `json:"login"`
+	DisplayName string `json:"display_name"`
+}
+
+// LowTrustUserSender describes the user who sent a message while being restricted or monitored
+type LowTrustUserSender struct {
+	UserID      string `json:"user_id"`
+	Login       string `json:"login"`
+	DisplayName string `json:"display_name"`
+	ChatColor   string `json:"chat_color"`
+	Badges      []struct {
+		ID      string `json:"id"`
+		Version string `json:"version"`
+	} `json:"badges"`
+}
+
+// LowTrustUserMessage describes a message sent by a restricted or monitored user
+type LowTrustUserMessage struct {
+	LowTrustUser LowTrustUser `json:"low_trust_user"`
+
+	MessageID      string `json:"message_id"`
+	MessageContent struct {
+		Text      string `json:"text"`
+		Fragments []struct {
+			Text     string `json:"text"`
+			Emoticon *struct {
+				EmoticonID    string `json:"emoticonID"`
+				EmoticonSetID string `json:"emoticonSetID"`
+			} `json:"emoticon"`
+		} `json:"fragments"`
+	} `json:"message_content"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+type outerLowTrustUserEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// parseLowTrustUserEvent parses any message sent on the low trust users topic
+// Returns nil without an error for message types we don't handle
+func parseLowTrustUserEvent(bytes []byte) (*LowTrustUserEvent, error) {
+	outer := &outerLowTrustUserEvent{}
+	err := json.Unmarshal(bytes, outer)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &LowTrustUserEvent{
+		Type:     outer.Type,
+		RawEvent: newRawEvent(bytes, outer),
+	}
+
+	switch outer.Type {
+	case LowTrustUserTreatmentUpdate:
+		data.TreatmentUpdate = &LowTrustUser{}
+		if err := json.Unmarshal(outer.Data, data.TreatmentUpdate); err != nil {
+			return nil, err
+		}
+		data.addUnknownFields("data", outer.Data, data.TreatmentUpdate)
+	case LowTrustUserNewMessage:
+		data.NewMessage = &LowTrustUserMessage{}
+		if err := json.Unmarshal(outer.Data, data.NewMessage); err != nil {
+			return nil, err
+		}
+		data.addUnknownFields("data", outer.Data, data.NewMessage)
+	default:
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+func parseChannelIDFromLowTrustUsersTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 {
+		return "", errors.New("unable to parse channel ID from low trust users topic")
+	}
+
+	return parts[2], nil
+}
+
+func isLowTrustUsersEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, lowTrustUsersEventTopicPrefix)
+}
+
+// LowTrustUsersEventTopic returns a properly formatted low trust users event topic string with the given moderator and channel ID arguments
+func LowTrustUsersEventTopic(modID, channelID string) string {
+	const f = `low-trust-users.%s.%s`
+	return fmt.Sprintf(f, modID, channelID)
+}
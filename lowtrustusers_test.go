@@ -0,0 +1,152 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseLowTrustUserEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *LowTrustUserEvent
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Treatment update",
+			input:      `{"type":"MESSAGE","data":{"topic":"low-trust-users.11148817.11148817","message":"{\"type\":\"low_trust_user_treatment_update\",\"data\":{\"low_trust_id\":\"MTE3MjMxNjN8MTE0ODg4MTc=\",\"channel_id\":\"11148817\",\"updated_by\":{\"id\":\"11148817\",\"login\":\"pajlada\",\"display_name\":\"pajlada\"},\"updated_at\":\"2023-06-17T15:04:31Z\",\"target_user_id\":\"133077169\",\"target_user\":\"slurps\",\"treatment\":\"RESTRICTED\",\"types\":[\"MANUALLY_ADDED\"],\"ban_evasion_evaluation\":\"LIKELY_EVADER\",\"evaluated_at\":\"2023-06-17T15:00:00Z\"}}"}}`,
+			isValidMsg: true,
+			expected: &LowTrustUserEvent{
+				Type: LowTrustUserTreatmentUpdate,
+				TreatmentUpdate: &LowTrustUser{
+					LowTrustID:           "MTE3MjMxNjN8MTE0ODg4MTc=",
+					ChannelID:            "11148817",
+					TargetUserID:         "133077169",
+					TargetUser:           "slurps",
+					Treatment:            LowTrustUserTreatmentRestricted,
+					Types:                []string{"MANUALLY_ADDED"},
+					BanEvasionEvaluation: BanEvasionEvaluationLikelyEvader,
+					EvaluatedAt:          time.Date(2023, time.June, 17, 15, 0, 0, 0, time.UTC),
+					UpdatedAt:            time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+					UpdatedBy: LowTrustUserUpdater{
+						ID:          "11148817",
+						Login:       "pajlada",
+						DisplayName: "pajlada",
+					},
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"low-trust-users.11148817.11148817","message":"{\"type\":\"low_trust_user_forsen\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"low-trust-users.11148817.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isLowTrustUsersEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseLowTrustUserEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseLowTrustUserNewMessage(t *testing.T) {
+	c := qt.New(t)
+
+	input := `{"type":"low_trust_user_new_message","data":{"low_trust_user":{"id":"133077169","low_trust_id":"MTE3MjMxNjN8MTE0ODg4MTc=","channel_id":"11148817","sender":{"user_id":"133077169","login":"slurps","display_name":"slurps","chat_color":"#FF0000","badges":[]},"evaluated_at":"2023-06-17T15:00:00Z","updated_at":"2023-06-17T15:04:31Z","ban_evasion_evaluation":"POSSIBLE_EVADER","treatment":"ACTIVE_MONITORING","updated_by":{"id":"11148817","login":"pajlada","display_name":"pajlada"},"types":["BANNED_IN_SHARED_CHANNEL"],"shared_ban_channel_ids":["22484632","71092938"]},"message_content":{"text":"hello Kappa","fragments":[{"text":"hello "},{"text":"Kappa","emoticon":{"emoticonID":"25","emoticonSetID":"0"}}]},"message_id":"5f0f5a5e-3b1c-4e7b-8b0b-6b4b4b0b8b0b","sent_at":"2023-06-17T15:05:00Z"}}`
+
+	actual, err := parseLowTrustUserEvent([]byte(input))
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual.Type, qt.Equals, LowTrustUserNewMessage)
+	c.Assert(actual.TreatmentUpdate, qt.IsNil)
+	c.Assert(actual.NewMessage, qt.IsNotNil)
+	c.Assert(actual.UnknownFields, qt.IsNil)
+
+	msg := actual.NewMessage
+	c.Assert(msg.MessageID, qt.Equals, "5f0f5a5e-3b1c-4e7b-8b0b-6b4b4b0b8b0b")
+	c.Assert(msg.SentAt, qt.Equals, time.Date(2023, time.June, 17, 15, 5, 0, 0, time.UTC))
+	c.Assert(msg.LowTrustUser.ID, qt.Equals, "133077169")
+	c.Assert(msg.LowTrustUser.Sender.Login, qt.Equals, "slurps")
+	c.Assert(msg.LowTrustUser.Treatment, qt.Equals, LowTrustUserTreatmentActiveMonitoring)
+	c.Assert(msg.LowTrustUser.BanEvasionEvaluation, qt.Equals, BanEvasionEvaluationPossibleEvader)
+	c.Assert(msg.LowTrustUser.SharedBanChannelIDs, qt.DeepEquals, []string{"22484632", "71092938"})
+	c.Assert(msg.MessageContent.Text, qt.Equals, "hello Kappa")
+	c.Assert(msg.MessageContent.Fragments, qt.HasLen, 2)
+	c.Assert(msg.MessageContent.Fragments[0].Emoticon, qt.IsNil)
+	c.Assert(msg.MessageContent.Fragments[1].Emoticon.EmoticonID, qt.Equals, "25")
+}
+
+func TestParseLowTrustUsersTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label             string
+		inputTopic        string
+		expectedChannelID string
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			label:             "Standard",
+			inputTopic:        LowTrustUsersEventTopic("123", "456"),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Malformed",
+			inputTopic:        "low-trust-users.123",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from low trust users topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actualChannelID, err := parseChannelIDFromLowTrustUsersTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(actualChannelID, qt.Equals, testCase.expectedChannelID)
+		})
+	}
+}
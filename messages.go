@@ -17,6 +17,23 @@ const (
 	messageTypeAutoModQueueEvent
 	messageTypeWhisperEvent
 	messageTypeSubscribeEvent
+	messageTypeBitsBadgeUnlockEvent
+	messageTypeLowTrustUserEvent
+	messageTypeUserModerationNotificationEvent
+	messageTypeRaidEvent
+	messageTypeMysteryGiftEvent
+	messageTypeLeaderboardUpdate
+	messageTypeChatRoomUpdate
+	messageTypeBroadcastSettingsUpdate
+	messageTypeChatroomsUserModerationAction
+	messageTypeShoutoutEvent
+	messageTypePinnedChatEvent
+	messageTypeUnbanRequestEvent
+	messageTypeCreatorGoalEvent
+	messageTypeCharityDonationEvent
+	messageTypeUserSubscribeEvent
+	messageTypeCommunityPointsUserEvent
+	messageTypeExtensionMessage
 )
 
 func getMessageType(topic string) messageType {
@@ -38,6 +55,57 @@ func getMessageType(topic string) messageType {
 	if isSubscribeEventTopic(topic) {
 		return messageTypeSubscribeEvent
 	}
+	if isBitsBadgeUnlockEventTopic(topic) {
+		return messageTypeBitsBadgeUnlockEvent
+	}
+	if isLowTrustUsersEventTopic(topic) {
+		return messageTypeLowTrustUserEvent
+	}
+	if isUserModerationNotificationEventTopic(topic) {
+		return messageTypeUserModerationNotificationEvent
+	}
+	if isRaidEventTopic(topic) {
+		return messageTypeRaidEvent
+	}
+	if isSubGiftsEventTopic(topic) {
+		return messageTypeMysteryGiftEvent
+	}
+	if isLeaderboardEventTopic(topic) {
+		return messageTypeLeaderboardUpdate
+	}
+	if isChatRoomEventTopic(topic) {
+		return messageTypeChatRoomUpdate
+	}
+	if isBroadcastSettingsEventTopic(topic) {
+		return messageTypeBroadcastSettingsUpdate
+	}
+	if isChatroomsUserEventTopic(topic) {
+		return messageTypeChatroomsUserModerationAction
+	}
+	if isShoutoutEventTopic(topic) {
+		return messageTypeShoutoutEvent
+	}
+	if isPinnedChatEventTopic(topic) {
+		return messageTypePinnedChatEvent
+	}
+	if isUnbanRequestsEventTopic(topic) {
+		return messageTypeUnbanRequestEvent
+	}
+	if isCreatorGoalsEventTopic(topic) {
+		return messageTypeCreatorGoalEvent
+	}
+	if isCharityDonationEventTopic(topic) {
+		return messageTypeCharityDonationEvent
+	}
+	if isUserSubscribeEventTopic(topic) {
+		return messageTypeUserSubscribeEvent
+	}
+	if isCommunityPointsUserEventTopic(topic) {
+		return messageTypeCommunityPointsUserEvent
+	}
+	if isExtensionEventTopic(topic) {
+		return messageTypeExtensionMessage
+	}
 
 	return messageTypeUnknown
 }
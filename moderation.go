@@ -4,11 +4,52 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const moderationActionTopicPrefix = "chat_moderator_actions."
 
+// Known values of ModerationAction.ModerationAction
+const (
+	ModerationActionBan             = "ban"
+	ModerationActionUnban           = "unban"
+	ModerationActionTimeout         = "timeout"
+	ModerationActionUntimeout       = "untimeout"
+	ModerationActionDelete          = "delete"
+	ModerationActionClear           = "clear"
+	ModerationActionSlow            = "slow"
+	ModerationActionSlowOff         = "slowoff"
+	ModerationActionFollowers       = "followers"
+	ModerationActionFollowersOff    = "followersoff"
+	ModerationActionEmoteOnly       = "emoteonly"
+	ModerationActionEmoteOnlyOff    = "emoteonlyoff"
+	ModerationActionSubscribers     = "subscribers"
+	ModerationActionSubscribersOff  = "subscribersoff"
+	ModerationActionR9KBeta         = "r9kbeta"
+	ModerationActionR9KBetaOff      = "r9kbetaoff"
+	ModerationActionMod             = "mod"
+	ModerationActionUnmod           = "unmod"
+	ModerationActionVIP             = "vip"
+	ModerationActionUnvip           = "unvip"
+	ModerationActionRaid            = "raid"
+	ModerationActionUnraid          = "unraid"
+	ModerationActionHost            = "host"
+	ModerationActionUnhost          = "unhost"
+	ModerationActionAutoModRejected = "automod_rejected"
+	ModerationActionApproveAutoMod  = "approved_automod_message"
+	ModerationActionDenyAutoMod     = "denied_automod_message"
+)
+
+var (
+	// ErrUnexpectedModerationAction is returned from a ModerationAction accessor when the action is of a different kind
+	ErrUnexpectedModerationAction = errors.New("go-twitch-pubsub: Unexpected moderation action")
+
+	// ErrMissingModerationArguments is returned from a ModerationAction accessor when Twitch sent fewer arguments than expected
+	ErrMissingModerationArguments = errors.New("go-twitch-pubsub: Missing moderation action arguments")
+)
+
 // ModerationAction describes an incoming "Moderation" action coming from Twitch's PubSub servers
 type ModerationAction struct {
 	Type             string   `json:"type"`
@@ -18,20 +59,335 @@ type ModerationAction struct {
 	CreatedByUserID  string   `json:"created_by_user_id"`
 	MsgID            string   `json:"msg_id"`
 	TargetUserID     string   `json:"target_user_id"`
+
+	RawEvent
+}
+
+// Timeout is the parsed form of a "timeout" moderation action
+type Timeout struct {
+	TargetLogin string
+	Duration    time.Duration
+	// Reason can be empty if no reason was given
+	Reason string
+}
+
+// Ban is the parsed form of a "ban" moderation action
+type Ban struct {
+	TargetLogin string
+	// Reason can be empty if no reason was given
+	Reason string
+}
+
+// Delete is the parsed form of a "delete" moderation action
+type Delete struct {
+	TargetLogin string
+	MessageID   string
+	Text        string
+}
+
+// AutoModRejected is the parsed form of an "automod_rejected" moderation action
+type AutoModRejected struct {
+	TargetLogin string
+	Text        string
+	Category    string
+}
+
+// ChatMode is the parsed form of a "slow" or "followers" moderation action
+type ChatMode struct {
+	// Duration is the slow mode delay, or the minimum follow age for followers-only mode
+	Duration time.Duration
+}
+
+// Timeout returns the parsed arguments of a "timeout" moderation action
+func (a *ModerationAction) Timeout() (*Timeout, error) {
+	if err := a.expect(2, ModerationActionTimeout); err != nil {
+		return nil, err
+	}
+
+	seconds, err := strconv.Atoi(a.Arguments[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Timeout{
+		TargetLogin: a.Arguments[0],
+		Duration:    time.Duration(seconds) * time.Second,
+		Reason:      a.argument(2),
+	}, nil
+}
+
+// Ban returns the parsed arguments of a "ban" moderation action
+func (a *ModerationAction) Ban() (*Ban, error) {
+	if err := a.expect(1, ModerationActionBan); err != nil {
+		return nil, err
+	}
+
+	return &Ban{
+		TargetLogin: a.Arguments[0],
+		Reason:      a.argument(1),
+	}, nil
+}
+
+// Delete returns the parsed arguments of a "delete" moderation action
+func (a *ModerationAction) Delete() (*Delete, error) {
+	if err := a.expect(3, ModerationActionDelete); err != nil {
+		return nil, err
+	}
+
+	return &Delete{
+		TargetLogin: a.Arguments[0],
+		Text:        a.Arguments[1],
+		MessageID:   a.Arguments[2],
+	}, nil
+}
+
+// AutoModRejected returns the parsed arguments of an "automod_rejected" moderation action
+func (a *ModerationAction) AutoModRejected() (*AutoModRejected, error) {
+	if err := a.expect(2, ModerationActionAutoModRejected); err != nil {
+		return nil, err
+	}
+
+	return &AutoModRejected{
+		TargetLogin: a.Arguments[0],
+		Text:        a.Arguments[1],
+		Category:    a.argument(2),
+	}, nil
+}
+
+// ChatMode returns the parsed arguments of a "slow" (seconds) or "followers" (minutes) moderation action
+func (a *ModerationAction) ChatMode() (*ChatMode, error) {
+	var unit time.Duration
+	switch a.ModerationAction {
+	case ModerationActionSlow:
+		unit = time.Second
+	case ModerationActionFollowers:
+		unit = time.Minute
+	default:
+		return nil, ErrUnexpectedModerationAction
+	}
+
+	// followers-only mode without a duration is sent with no arguments
+	value := a.argument(0)
+	if value == "" {
+		return &ChatMode{}, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatMode{
+		Duration: time.Duration(n) * unit,
+	}, nil
+}
+
+// TargetLogin returns the login name of the user or channel the moderation action was performed on
+// This works for every action whose first argument is a login name, e.g. ban, unban, timeout, untimeout, delete, mod, unmod, vip, unvip, raid and host
+func (a *ModerationAction) TargetLogin() (string, error) {
+	switch a.ModerationAction {
+	case ModerationActionBan, ModerationActionUnban,
+		ModerationActionTimeout, ModerationActionUntimeout,
+		ModerationActionDelete,
+		ModerationActionMod, ModerationActionUnmod,
+		ModerationActionVIP, ModerationActionUnvip,
+		ModerationActionRaid, ModerationActionHost,
+		ModerationActionAutoModRejected, ModerationActionApproveAutoMod, ModerationActionDenyAutoMod:
+	default:
+		return "", ErrUnexpectedModerationAction
+	}
+
+	if len(a.Arguments) < 1 {
+		return "", ErrMissingModerationArguments
+	}
+
+	return a.Arguments[0], nil
+}
+
+func (a *ModerationAction) expect(minArguments int, action string) error {
+	if a.ModerationAction != action {
+		return ErrUnexpectedModerationAction
+	}
+
+	if len(a.Arguments) < minArguments {
+		return ErrMissingModerationArguments
+	}
+
+	return nil
+}
+
+// argument returns the argument at index i, or an empty string if it was not sent
+func (a *ModerationAction) argument(i int) string {
+	if i < len(a.Arguments) {
+		return a.Arguments[i]
+	}
+
+	return ""
+}
+
+// Known values of the outer message type on the moderation topic
+const (
+	moderationMessageTypeModerationAction    = "moderation_action"
+	moderationMessageTypeModeratorAdded      = "moderator_added"
+	moderationMessageTypeModeratorRemoved    = "moderator_removed"
+	moderationMessageTypeVIPAdded            = "vip_added"
+	moderationMessageTypeChannelTermsAction  = "channel_terms_action"
+	moderationMessageTypeApproveUnbanRequest = "approve_unban_request"
+	moderationMessageTypeDenyUnbanRequest    = "deny_unban_request"
+)
+
+// Known values of RoleChangeEvent.Type
+const (
+	RoleChangeModeratorAdded   = moderationMessageTypeModeratorAdded
+	RoleChangeModeratorRemoved = moderationMessageTypeModeratorRemoved
+	RoleChangeVIPAdded         = moderationMessageTypeVIPAdded
+)
+
+// RoleChangeEvent describes a user being added or removed as a moderator, or added as a VIP, coming from the moderation topic
+type RoleChangeEvent struct {
+	// Type is one of RoleChangeModeratorAdded, RoleChangeModeratorRemoved or RoleChangeVIPAdded
+	Type string `json:"-"`
+
+	// ModerationAction is the chat command the role was changed with, e.g. "mod" or "unmod"
+	// It is not sent for every role change
+	ModerationAction string `json:"moderation_action"`
+
+	ChannelID       string `json:"channel_id"`
+	TargetUserID    string `json:"target_user_id"`
+	TargetUserLogin string `json:"target_user_login"`
+	CreatedBy       string `json:"created_by"`
+	CreatedByUserID string `json:"created_by_user_id"`
+
+	RawEvent
+}
+
+// Known values of ChannelTermsEvent.Type
+const (
+	ChannelTermsAddBlockedTerm      = "add_blocked_term"
+	ChannelTermsDeleteBlockedTerm   = "delete_blocked_term"
+	ChannelTermsAddPermittedTerm    = "add_permitted_term"
+	ChannelTermsDeletePermittedTerm = "delete_permitted_term"
+)
+
+// ChannelTermsEvent describes a blocked or permitted term being added or removed, coming from the moderation topic
+type ChannelTermsEvent struct {
+	// Type is one of the ChannelTerms* constants
+	Type string `json:"type"`
+
+	ID             string    `json:"id"`
+	Text           string    `json:"text"`
+	RequesterID    string    `json:"requester_id"`
+	RequesterLogin string    `json:"requester_login"`
+	ChannelID      string    `json:"channel_id"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	FromAutoMod    bool      `json:"from_automod"`
+
+	// ExpiresAt is empty if the term does not expire
+	ExpiresAt string `json:"expires_at"`
+
+	RawEvent
+}
+
+// Known values of UnbanRequestActionEvent.Type
+const (
+	UnbanRequestActionApproved = moderationMessageTypeApproveUnbanRequest
+	UnbanRequestActionDenied   = moderationMessageTypeDenyUnbanRequest
+)
+
+// UnbanRequestActionEvent describes a moderator approving or denying an unban request, coming from the moderation topic
+type UnbanRequestActionEvent struct {
+	// Type is either UnbanRequestActionApproved or UnbanRequestActionDenied
+	Type string `json:"-"`
+
+	// ModerationAction is the action as sent in the message data, which doesn't use the same casing as Type
+	ModerationAction string `json:"moderation_action"`
+
+	CreatedByID      string `json:"created_by_id"`
+	CreatedByLogin   string `json:"created_by_login"`
+	ModeratorMessage string `json:"moderator_message"`
+	TargetUserID     string `json:"target_user_id"`
+	TargetUserLogin  string `json:"target_user_login"`
+
+	RawEvent
+}
+
+// IsApproved returns true if the unban request was approved
+func (e *UnbanRequestActionEvent) IsApproved() bool {
+	return e.Type == UnbanRequestActionApproved
 }
 
 type outerModerationAction struct {
-	Data ModerationAction `json:"data"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// parseModerationMessage parses any message sent on the moderation topic
+// The returned value is one of *ModerationAction, *RoleChangeEvent, *ChannelTermsEvent or *UnbanRequestActionEvent
+func parseModerationMessage(bytes []byte) (interface{}, error) {
+	outer := &outerModerationAction{}
+	err := json.Unmarshal(bytes, outer)
+	if err != nil {
+		return nil, err
+	}
+
+	switch outer.Type {
+	case moderationMessageTypeModeratorAdded, moderationMessageTypeModeratorRemoved, moderationMessageTypeVIPAdded:
+		data := &RoleChangeEvent{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		data.Type = outer.Type
+		data.RawEvent = newModerationRawEvent(bytes, outer, data)
+		return data, nil
+
+	case moderationMessageTypeChannelTermsAction:
+		data := &ChannelTermsEvent{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		data.RawEvent = newModerationRawEvent(bytes, outer, data)
+		return data, nil
+
+	case moderationMessageTypeApproveUnbanRequest, moderationMessageTypeDenyUnbanRequest:
+		data := &UnbanRequestActionEvent{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		data.Type = outer.Type
+		data.RawEvent = newModerationRawEvent(bytes, outer, data)
+		return data, nil
+
+	default:
+		// Anything we don't recognize is treated as a moderation action, which is how this topic was always parsed
+		data, err := parseModerationActionData(outer.Data)
+		if err != nil {
+			return nil, err
+		}
+		data.RawEvent = newModerationRawEvent(bytes, outer, data)
+		return data, nil
+	}
+}
+
+// newModerationRawEvent returns the RawEvent for a moderation message, whose data field was unmarshaled into data
+func newModerationRawEvent(bytes []byte, outer *outerModerationAction, data interface{}) RawEvent {
+	raw := newRawEvent(bytes, outer)
+	raw.addUnknownFields("data", outer.Data, data)
+
+	return raw
 }
 
-func parseModerationAction(bytes []byte) (*ModerationAction, error) {
-	data := &outerModerationAction{}
+func parseModerationActionData(bytes json.RawMessage) (*ModerationAction, error) {
+	data := &ModerationAction{}
+	if len(bytes) == 0 {
+		return data, nil
+	}
+
 	err := json.Unmarshal(bytes, data)
 	if err != nil {
 		return nil, err
 	}
 
-	return &data.Data, nil
+	return data, nil
 }
 
 func parseChannelIDFromModerationTopic(topic string) (string, error) {
@@ -3,6 +3,7 @@ package twitchpubsub
 import (
 	"errors"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 )
@@ -14,7 +15,7 @@ func TestParseModerationEvent(t *testing.T) {
 		label            string
 		input            string
 		isValidMsg       bool
-		expected         *ModerationAction
+		expected         interface{}
 		expectedErr      error
 		expectedOuterErr error
 	}
@@ -92,19 +93,148 @@ func TestParseModerationEvent(t *testing.T) {
 			if testCase.isValidMsg {
 				// Only test parsing if we expect the input message to be an actual subscribe message
 				innerMessageBytes := []byte(outerMessage.Data.Message)
-				actual, err := parseModerationAction([]byte(innerMessageBytes))
+				actual, err := parseModerationMessage([]byte(innerMessageBytes))
 
 				if testCase.expectedErr == nil {
 					c.Assert(err, qt.IsNil)
 				} else {
 					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
 				}
-				c.Assert(actual, qt.DeepEquals, testCase.expected)
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
 			}
 		})
 	}
 }
 
+func TestParseModerationMessage(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label       string
+		input       string
+		expected    interface{}
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			label: "Moderation action",
+			input: `{"type":"moderation_action","data":{"type":"chat_login_moderation","moderation_action":"unban","args":["forsen"],"created_by":"pajlada","created_by_user_id":"11148817","target_user_id":"22484632"}}`,
+			expected: &ModerationAction{
+				Type:             "chat_login_moderation",
+				ModerationAction: "unban",
+				Arguments:        []string{"forsen"},
+				CreatedBy:        "pajlada",
+				CreatedByUserID:  "11148817",
+				TargetUserID:     "22484632",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Moderator added",
+			input: `{"type":"moderator_added","data":{"channel_id":"11148817","target_user_id":"129546453","moderation_action":"mod","target_user_login":"nerixyz","created_by_user_id":"11148817","created_by":"pajlada"}}`,
+			expected: &RoleChangeEvent{
+				Type:             RoleChangeModeratorAdded,
+				ModerationAction: "mod",
+				ChannelID:        "11148817",
+				TargetUserID:     "129546453",
+				TargetUserLogin:  "nerixyz",
+				CreatedBy:        "pajlada",
+				CreatedByUserID:  "11148817",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "VIP added",
+			input: `{"type":"vip_added","data":{"channel_id":"11148817","target_user_id":"129546453","target_user_login":"nerixyz","created_by_user_id":"11148817","created_by":"pajlada"}}`,
+			expected: &RoleChangeEvent{
+				Type:            RoleChangeVIPAdded,
+				ChannelID:       "11148817",
+				TargetUserID:    "129546453",
+				TargetUserLogin: "nerixyz",
+				CreatedBy:       "pajlada",
+				CreatedByUserID: "11148817",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Blocked term added",
+			input: `{"type":"channel_terms_action","data":{"type":"add_blocked_term","id":"a3b4b6b2-6b4b-4b0b-8b0b-6b4b4b0b8b0b","text":"forsen","requester_id":"11148817","requester_login":"pajlada","channel_id":"11148817","expires_at":"","updated_at":"2023-06-17T15:04:31.20928599Z","from_automod":false}}`,
+			expected: &ChannelTermsEvent{
+				Type:           ChannelTermsAddBlockedTerm,
+				ID:             "a3b4b6b2-6b4b-4b0b-8b0b-6b4b4b0b8b0b",
+				Text:           "forsen",
+				RequesterID:    "11148817",
+				RequesterLogin: "pajlada",
+				ChannelID:      "11148817",
+				UpdatedAt:      time.Date(2023, time.June, 17, 15, 4, 31, 209285990, time.UTC),
+				FromAutoMod:    false,
+				ExpiresAt:      "",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Unban request approved",
+			input: `{"type":"approve_unban_request","data":{"moderation_action":"APPROVE_UNBAN_REQUEST","created_by_id":"11148817","created_by_login":"pajlada","moderator_message":"ok","target_user_id":"133077169","target_user_login":"slurps"}}`,
+			expected: &UnbanRequestActionEvent{
+				Type:             UnbanRequestActionApproved,
+				ModerationAction: "APPROVE_UNBAN_REQUEST",
+				CreatedByID:      "11148817",
+				CreatedByLogin:   "pajlada",
+				ModeratorMessage: "ok",
+				TargetUserID:     "133077169",
+				TargetUserLogin:  "slurps",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Unban request denied",
+			input: `{"type":"deny_unban_request","data":{"moderation_action":"deny_unban_request","created_by_id":"11148817","created_by_login":"pajlada","moderator_message":"no","target_user_id":"133077169","target_user_login":"slurps"}}`,
+			expected: &UnbanRequestActionEvent{
+				Type:             UnbanRequestActionDenied,
+				ModerationAction: "deny_unban_request",
+				CreatedByID:      "11148817",
+				CreatedByLogin:   "pajlada",
+				ModeratorMessage: "no",
+				TargetUserID:     "133077169",
+				TargetUserLogin:  "slurps",
+			},
+			expectedErr: nil,
+		},
+		{
+			label:       "Invalid message JSON",
+			input:       `{forsen}`,
+			expected:    nil,
+			expectedErr: errors.New("invalid character 'f' looking for beginning of object key string"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual, err := parseModerationMessage([]byte(testCase.input))
+
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestUnbanRequestActionIsApproved(t *testing.T) {
+	c := qt.New(t)
+
+	approved, err := parseModerationMessage([]byte(`{"type":"approve_unban_request","data":{"moderation_action":"APPROVE_UNBAN_REQUEST","created_by_id":"11148817","created_by_login":"pajlada","target_user_id":"133077169","target_user_login":"slurps"}}`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(approved.(*UnbanRequestActionEvent).IsApproved(), qt.IsTrue)
+
+	denied, err := parseModerationMessage([]byte(`{"type":"deny_unban_request","data":{"moderation_action":"DENY_UNBAN_REQUEST","created_by_id":"11148817","created_by_login":"pajlada","target_user_id":"133077169","target_user_login":"slurps"}}`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(denied.(*UnbanRequestActionEvent).IsApproved(), qt.IsFalse)
+}
+
 func TestCreateModerationTopic(t *testing.T) {
 	c := qt.New(t)
 
@@ -181,3 +311,178 @@ func TestParseModerationTopicChannelID(t *testing.T) {
 		})
 	}
 }
+
+func TestModerationActionTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label       string
+		input       *ModerationAction
+		expected    *Timeout
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			label: "Timeout without reason",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionTimeout,
+				Arguments:        []string{"69420", "1", ""},
+			},
+			expected: &Timeout{
+				TargetLogin: "69420",
+				Duration:    1 * time.Second,
+				Reason:      "",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Timeout with reason",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionTimeout,
+				Arguments:        []string{"doge41732", "600", "This is the reason for the timeout"},
+			},
+			expected: &Timeout{
+				TargetLogin: "doge41732",
+				Duration:    10 * time.Minute,
+				Reason:      "This is the reason for the timeout",
+			},
+			expectedErr: nil,
+		},
+		{
+			label: "Missing arguments",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionTimeout,
+				Arguments:        []string{"doge41732"},
+			},
+			expected:    nil,
+			expectedErr: ErrMissingModerationArguments,
+		},
+		{
+			label: "Not a timeout",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionBan,
+				Arguments:        []string{"doge41732", "5"},
+			},
+			expected:    nil,
+			expectedErr: ErrUnexpectedModerationAction,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual, err := testCase.input.Timeout()
+			c.Assert(err, qt.Equals, testCase.expectedErr)
+			c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+		})
+	}
+}
+
+func TestModerationActionBan(t *testing.T) {
+	c := qt.New(t)
+
+	actual, err := (&ModerationAction{
+		ModerationAction: ModerationActionBan,
+		Arguments:        []string{"forsen"},
+	}).Ban()
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual, qt.DeepEquals, &Ban{TargetLogin: "forsen"})
+
+	actual, err = (&ModerationAction{
+		ModerationAction: ModerationActionBan,
+		Arguments:        []string{"forsen", "spam"},
+	}).Ban()
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual, qt.DeepEquals, &Ban{TargetLogin: "forsen", Reason: "spam"})
+}
+
+func TestModerationActionDelete(t *testing.T) {
+	c := qt.New(t)
+
+	actual, err := (&ModerationAction{
+		ModerationAction: ModerationActionDelete,
+		Arguments: []string{
+			"slurps",
+			"4HEad 👍",
+			"31197cd8-d5da-4deb-a146-3d8b5115518a",
+		},
+	}).Delete()
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual, qt.DeepEquals, &Delete{
+		TargetLogin: "slurps",
+		MessageID:   "31197cd8-d5da-4deb-a146-3d8b5115518a",
+		Text:        "4HEad 👍",
+	})
+}
+
+func TestModerationActionChatMode(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label       string
+		input       *ModerationAction
+		expected    *ChatMode
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			label: "Slow",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionSlow,
+				Arguments:        []string{"30"},
+			},
+			expected:    &ChatMode{Duration: 30 * time.Second},
+			expectedErr: nil,
+		},
+		{
+			label: "Followers",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionFollowers,
+				Arguments:        []string{"10"},
+			},
+			expected:    &ChatMode{Duration: 10 * time.Minute},
+			expectedErr: nil,
+		},
+		{
+			label: "Followers without duration",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionFollowers,
+			},
+			expected:    &ChatMode{},
+			expectedErr: nil,
+		},
+		{
+			label: "Clear",
+			input: &ModerationAction{
+				ModerationAction: ModerationActionClear,
+			},
+			expected:    nil,
+			expectedErr: ErrUnexpectedModerationAction,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual, err := testCase.input.ChatMode()
+			c.Assert(err, qt.Equals, testCase.expectedErr)
+			c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+		})
+	}
+}
+
+func TestModerationActionTargetLogin(t *testing.T) {
+	c := qt.New(t)
+
+	actual, err := (&ModerationAction{
+		ModerationAction: ModerationActionVIP,
+		Arguments:        []string{"nerixyz"},
+	}).TargetLogin()
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual, qt.Equals, "nerixyz")
+
+	_, err = (&ModerationAction{
+		ModerationAction: ModerationActionEmoteOnly,
+	}).TargetLogin()
+	c.Assert(err, qt.Equals, ErrUnexpectedModerationAction)
+}
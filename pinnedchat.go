@@ -0,0 +1,318 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch pinned chat message events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const pinnedChatEventTopicPrefix = "pinned-chat-updates-v1."
+
+// Known values of the outer message type on the pinned chat topic
+const (
+	pinnedChatMessageTypePin    = "pin-message"
+	pinnedChatMessageTypeUpdate = "update-message"
+	pinnedChatMessageTypeUnpin  = "unpin-message"
+)
+
+// PinnedChatUser describes a user who pinned or unpinned a message
+type PinnedChatUser struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+// PinnedChatSender describes the user who sent a pinned message
+type PinnedChatSender struct {
+	ID          string            `json:"id"`
+	DisplayName string            `json:"display_name"`
+	ChatColor   string            `json:"chat_color"`
+	Badges      []PinnedChatBadge `json:"badges"`
+}
+
+// PinnedChatBadge is a chat badge displayed next to the sender of a pinned message
+type PinnedChatBadge struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// PinnedChatMessage describes a message that was pinned in chat
+type PinnedChatMessage struct {
+	ID        string
+	Sender    PinnedChatSender
+	Text      string
+	Fragments []Fragment
+
+	// Type is the kind of pin, e.g. "MOD"
+	Type string
+
+	StartsAt  time.Time
+	UpdatedAt time.Time
+	// EndsAt is zero if the pin does not expire
+	EndsAt time.Time
+	SentAt time.Time
+}
+
+// PinCreated is sent when a message is pinned
+type PinCreated struct {
+	PinID    string
+	PinnedBy PinnedChatUser
+	Message  PinnedChatMessage
+
+	RawEvent
+}
+
+// clone returns a deep copy of the pin, so it shares no slices or maps with p
+func (p PinCreated) clone() PinCreated {
+	if p.Message.Sender.Badges != nil {
+		p.Message.Sender.Badges = append([]PinnedChatBadge{}, p.Message.Sender.Badges...)
+	}
+	if p.Message.Fragments != nil {
+		fragments := make([]Fragment, len(p.Message.Fragments))
+		for i, fragment := range p.Message.Fragments {
+			if fragment.AutoModTopics != nil {
+				topics := make(map[string]int, len(fragment.AutoModTopics))
+				for topic, level := range fragment.AutoModTopics {
+					topics[topic] = level
+				}
+				fragment.AutoModTopics = topics
+			}
+			fragments[i] = fragment
+		}
+		p.Message.Fragments = fragments
+	}
+	p.RawEvent = p.RawEvent.clone()
+
+	return p
+}
+
+// PinUpdated is sent when the duration of a pinned message is changed
+type PinUpdated struct {
+	PinID     string
+	MessageID string
+	// EndsAt is zero if the pin no longer expires
+	EndsAt    time.Time
+	UpdatedAt time.Time
+
+	RawEvent
+}
+
+// PinDeleted is sent when a message is unpinned
+type PinDeleted struct {
+	PinID      string
+	UnpinnedBy PinnedChatUser
+	// Reason is why the message was unpinned, e.g. "UNPIN" or "DELETE"
+	Reason string
+
+	RawEvent
+}
+
+type outerPinnedChatEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// pinnedChatFragment is a part of a pinned message's text
+type pinnedChatFragment struct {
+	Text string `json:"text"`
+	// Emote is nil unless this fragment is an emote
+	Emote *struct {
+		ID string `json:"id"`
+	} `json:"emote"`
+}
+
+type pinCreatedData struct {
+	ID       string         `json:"id"`
+	PinnedBy PinnedChatUser `json:"pinned_by"`
+	Message  struct {
+		ID      string           `json:"id"`
+		Sender  PinnedChatSender `json:"sender"`
+		Content struct {
+			Text      string               `json:"text"`
+			Fragments []pinnedChatFragment `json:"fragments"`
+		} `json:"content"`
+		Type      string `json:"type"`
+		StartsAt  int64  `json:"starts_at"`
+		UpdatedAt int64  `json:"updated_at"`
+		EndsAt    int64  `json:"ends_at"`
+		SentAt    int64  `json:"sent_at"`
+	} `json:"message"`
+}
+
+type pinUpdatedData struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+	EndsAt    int64  `json:"ends_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type pinDeletedData struct {
+	ID         string         `json:"id"`
+	UnpinnedBy PinnedChatUser `json:"unpinned_by"`
+	Reason     string         `json:"reason"`
+}
+
+// unixTime converts a unix timestamp in seconds to a time.Time, keeping 0 as the zero time
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(seconds, 0).UTC()
+}
+
+// parsePinnedChatEvent parses any message sent on the pinned chat topic
+// The returned value is one of *PinCreated, *PinUpdated or *PinDeleted
+// Returns nil without an error for message types we don't handle
+func parsePinnedChatEvent(bytes []byte) (interface{}, error) {
+	outer := &outerPinnedChatEvent{}
+	err := json.Unmarshal(bytes, outer)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newRawEvent(bytes, outer)
+
+	switch outer.Type {
+	case pinnedChatMessageTypePin:
+		data := &pinCreatedData{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		raw.addUnknownFields("data", outer.Data, data)
+		return &PinCreated{
+			PinID:    data.ID,
+			PinnedBy: data.PinnedBy,
+			Message: PinnedChatMessage{
+				ID:        data.Message.ID,
+				Sender:    data.Message.Sender,
+				Text:      data.Message.Content.Text,
+				Fragments: pinnedChatFragments(data.Message.Content.Fragments),
+				Type:      data.Message.Type,
+				StartsAt:  unixTime(data.Message.StartsAt),
+				UpdatedAt: unixTime(data.Message.UpdatedAt),
+				EndsAt:    unixTime(data.Message.EndsAt),
+				SentAt:    unixTime(data.Message.SentAt),
+			},
+			RawEvent: raw,
+		}, nil
+
+	case pinnedChatMessageTypeUpdate:
+		data := &pinUpdatedData{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		raw.addUnknownFields("data", outer.Data, data)
+		return &PinUpdated{
+			PinID:     data.ID,
+			MessageID: data.MessageID,
+			EndsAt:    unixTime(data.EndsAt),
+			UpdatedAt: unixTime(data.UpdatedAt),
+			RawEvent:  raw,
+		}, nil
+
+	case pinnedChatMessageTypeUnpin:
+		data := &pinDeletedData{}
+		if err := json.Unmarshal(outer.Data, data); err != nil {
+			return nil, err
+		}
+		raw.addUnknownFields("data", outer.Data, data)
+		return &PinDeleted{
+			PinID:      data.ID,
+			UnpinnedBy: data.UnpinnedBy,
+			Reason:     data.Reason,
+			RawEvent:   raw,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func parseChannelIDFromPinnedChatTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from pinned chat topic")
+	}
+
+	return parts[1], nil
+}
+
+func isPinnedChatEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, pinnedChatEventTopicPrefix)
+}
+
+// PinnedChatEventTopic returns a properly formatted pinned chat event topic string with the given channel ID argument
+func PinnedChatEventTopic(channelID string) string {
+	const f = `pinned-chat-updates-v1.%s`
+	return fmt.Sprintf(f, channelID)
+}
+
+// pinnedChatTracker keeps the currently pinned message for each channel
+// Pins are forgotten once they are unpinned or their EndsAt has passed
+type pinnedChatTracker struct {
+	mutex sync.Mutex
+	pins  map[string]PinCreated
+
+	now func() time.Time
+}
+
+func newPinnedChatTracker() *pinnedChatTracker {
+	return &pinnedChatTracker{
+		pins: make(map[string]PinCreated),
+		now:  time.Now,
+	}
+}
+
+func (t *pinnedChatTracker) created(channelID string, event *PinCreated) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pins[channelID] = event.clone()
+}
+
+func (t *pinnedChatTracker) updated(channelID string, event *PinUpdated) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	pin, ok := t.pins[channelID]
+	if !ok || pin.PinID != event.PinID {
+		return
+	}
+
+	pin.Message.EndsAt = event.EndsAt
+	pin.Message.UpdatedAt = event.UpdatedAt
+	t.pins[channelID] = pin
+}
+
+func (t *pinnedChatTracker) deleted(channelID string, event *PinDeleted) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	pin, ok := t.pins[channelID]
+	if !ok || pin.PinID != event.PinID {
+		return
+	}
+
+	delete(t.pins, channelID)
+}
+
+func (t *pinnedChatTracker) get(channelID string) (PinCreated, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	pin, ok := t.pins[channelID]
+	if !ok {
+		return PinCreated{}, false
+	}
+
+	if !pin.Message.EndsAt.IsZero() && !t.now().Before(pin.Message.EndsAt) {
+		delete(t.pins, channelID)
+		return PinCreated{}, false
+	}
+
+	return pin.clone(), true
+}
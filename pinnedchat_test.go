@@ -0,0 +1,216 @@
+package twitchpubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParsePinnedChatEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Pin",
+			input:      `{"type":"MESSAGE","data":{"topic":"pinned-chat-updates-v1.11148817","message":"{\"type\":\"pin-message\",\"data\":{\"id\":\"b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"pinned_by\":{\"id\":\"11148817\",\"display_name\":\"pajlada\"},\"message\":{\"id\":\"31197cd8-d5da-4deb-a146-3d8b5115518a\",\"sender\":{\"id\":\"133077169\",\"display_name\":\"slurps\",\"badges\":[{\"id\":\"moderator\",\"version\":\"1\"}],\"chat_color\":\"#FF0000\"},\"content\":{\"text\":\"hello Kappa\",\"fragments\":[{\"text\":\"hello \"},{\"text\":\"Kappa\",\"emote\":{\"id\":\"25\"}}]},\"type\":\"MOD\",\"starts_at\":1687014271,\"updated_at\":1687014271,\"ends_at\":1687014571,\"sent_at\":1687014260}}}"}}`,
+			isValidMsg: true,
+			expected: &PinCreated{
+				PinID: "b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b",
+				PinnedBy: PinnedChatUser{
+					ID:          "11148817",
+					DisplayName: "pajlada",
+				},
+				Message: PinnedChatMessage{
+					ID: "31197cd8-d5da-4deb-a146-3d8b5115518a",
+					Sender: PinnedChatSender{
+						ID:          "133077169",
+						DisplayName: "slurps",
+						ChatColor:   "#FF0000",
+						Badges: []PinnedChatBadge{
+							{ID: "moderator", Version: "1"},
+						},
+					},
+					Text: "hello Kappa",
+					Fragments: []Fragment{
+						{Type: FragmentTypeText, Text: "hello "},
+						{Type: FragmentTypeEmote, Text: "Kappa", EmoteID: "25"},
+					},
+					Type:      "MOD",
+					StartsAt:  time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+					UpdatedAt: time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+					EndsAt:    time.Date(2023, time.June, 17, 15, 9, 31, 0, time.UTC),
+					SentAt:    time.Date(2023, time.June, 17, 15, 4, 20, 0, time.UTC),
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Update",
+			input:      `{"type":"MESSAGE","data":{"topic":"pinned-chat-updates-v1.11148817","message":"{\"type\":\"update-message\",\"data\":{\"id\":\"b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"message_id\":\"31197cd8-d5da-4deb-a146-3d8b5115518a\",\"ends_at\":0,\"updated_at\":1687014300}}"}}`,
+			isValidMsg: true,
+			expected: &PinUpdated{
+				PinID:     "b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b",
+				MessageID: "31197cd8-d5da-4deb-a146-3d8b5115518a",
+				EndsAt:    time.Time{},
+				UpdatedAt: time.Date(2023, time.June, 17, 15, 5, 0, 0, time.UTC),
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Unpin",
+			input:      `{"type":"MESSAGE","data":{"topic":"pinned-chat-updates-v1.11148817","message":"{\"type\":\"unpin-message\",\"data\":{\"id\":\"b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"unpinned_by\":{\"id\":\"11148817\",\"display_name\":\"pajlada\"},\"reason\":\"UNPIN\"}}"}}`,
+			isValidMsg: true,
+			expected: &PinDeleted{
+				PinID: "b4e5a8f0-5b0b-4b0b-8b0b-6b4b4b0b8b0b",
+				UnpinnedBy: PinnedChatUser{
+					ID:          "11148817",
+					DisplayName: "pajlada",
+				},
+				Reason: "UNPIN",
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"pinned-chat-updates-v1.11148817","message":"{\"type\":\"forsen\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"pinned-chat-updates-v1.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isPinnedChatEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parsePinnedChatEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestPinnedChatTracker(t *testing.T) {
+	c := qt.New(t)
+
+	now := time.Date(2023, time.June, 17, 15, 5, 0, 0, time.UTC)
+	tracker := newPinnedChatTracker()
+	tracker.now = func() time.Time {
+		return now
+	}
+
+	_, ok := tracker.get("11148817")
+	c.Assert(ok, qt.IsFalse)
+
+	tracker.created("11148817", &PinCreated{
+		PinID: "pin1",
+		Message: PinnedChatMessage{
+			ID:     "message1",
+			EndsAt: now.Add(time.Minute),
+		},
+	})
+
+	pin, ok := tracker.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(pin.Message.ID, qt.Equals, "message1")
+
+	// Updates for other pins are ignored
+	tracker.updated("11148817", &PinUpdated{PinID: "pin2", EndsAt: now.Add(-time.Minute)})
+	_, ok = tracker.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+
+	// Expired pins are forgotten
+	now = now.Add(2 * time.Minute)
+	_, ok = tracker.get("11148817")
+	c.Assert(ok, qt.IsFalse)
+
+	// Pins without an end time never expire
+	tracker.created("11148817", &PinCreated{PinID: "pin3"})
+	tracker.updated("11148817", &PinUpdated{PinID: "pin3", EndsAt: time.Time{}})
+	now = now.Add(24 * time.Hour)
+	pin, ok = tracker.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(pin.PinID, qt.Equals, "pin3")
+
+	tracker.deleted("11148817", &PinDeleted{PinID: "pin3"})
+	_, ok = tracker.get("11148817")
+	c.Assert(ok, qt.IsFalse)
+}
+
+func TestPinnedChatTrackerReturnsCopy(t *testing.T) {
+	c := qt.New(t)
+
+	tracker := newPinnedChatTracker()
+
+	event := &PinCreated{
+		PinID: "pin1",
+		Message: PinnedChatMessage{
+			Sender: PinnedChatSender{
+				Badges: []PinnedChatBadge{{ID: "moderator", Version: "1"}},
+			},
+			Fragments: []Fragment{{Type: FragmentTypeText, Text: "hello"}},
+		},
+		RawEvent: RawEvent{
+			UnknownFields: map[string]json.RawMessage{"data.new_field": json.RawMessage(`1`)},
+		},
+	}
+	tracker.created("11148817", event)
+	event.Message.Fragments[0].Text = "changed by the callback"
+
+	pin, ok := tracker.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	pin.Message.Sender.Badges[0].ID = "vip"
+	pin.Message.Fragments[0].Text = "changed by the caller"
+	pin.UnknownFields["data.other_field"] = json.RawMessage(`2`)
+
+	pin, ok = tracker.get("11148817")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(pin.Message.Sender.Badges, qt.DeepEquals, []PinnedChatBadge{{ID: "moderator", Version: "1"}})
+	c.Assert(pin.Message.Fragments, qt.DeepEquals, []Fragment{{Type: FragmentTypeText, Text: "hello"}})
+	c.Assert(pin.UnknownFields, qt.DeepEquals, map[string]json.RawMessage{"data.new_field": json.RawMessage(`1`)})
+}
+
+func TestParsePinnedChatTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromPinnedChatTopic(PinnedChatEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromPinnedChatTopic("pinned-chat-updates-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from pinned chat topic")
+}
@@ -47,13 +47,17 @@ type PointsEvent struct {
 	} `json:"reward"`
 	UserInput  string   `json:"user_input,omitempty"`
 	Status     string   `json:"status"`
+
+	RawEvent
 }
 
 type outerPointsEvent struct {
+	Type string          `json:"type"`
 	Data pointsEventData `json:"data"`
 }
 
 type pointsEventData struct {
+	Timestamp  time.Time   `json:"timestamp"`
 	Redemption PointsEvent `json:"redemption"`
 }
 
@@ -63,6 +67,7 @@ func parsePointsEvent(bytes []byte) (*PointsEvent, error) {
 	if err != nil {
 		return nil, err
 	}
+	data.Data.Redemption.RawEvent = newRawEvent(bytes, data)
 	return &data.Data.Redemption, nil
 }
 
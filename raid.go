@@ -0,0 +1,126 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch raid events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const raidEventTopicPrefix = "raid."
+
+// Known values of the outer message type on the raid topic
+const (
+	raidMessageTypeGo       = "raid_go_v2"
+	raidMessageTypeUpdate   = "raid_update"
+	raidMessageTypeUpdateV2 = "raid_update_v2"
+	raidMessageTypeCancel   = "raid_cancel_v2"
+)
+
+// Raid describes an outgoing raid, shared by all raid events
+type Raid struct {
+	ID        string `json:"id"`
+	CreatorID string `json:"creator_id"`
+	SourceID  string `json:"source_id"`
+
+	TargetID           string `json:"target_id"`
+	TargetLogin        string `json:"target_login"`
+	TargetDisplayName  string `json:"target_display_name"`
+	TargetProfileImage string `json:"target_profile_image"`
+
+	ViewerCount int `json:"viewer_count"`
+
+	// TransitionJitterSeconds is only sent in v2 events
+	TransitionJitterSeconds int `json:"transition_jitter_seconds"`
+	// ForceRaidNowSeconds is the number of seconds left until the raid happens, only sent in v2 events
+	ForceRaidNowSeconds int `json:"force_raid_now_seconds"`
+
+	// RemainingDurationSeconds is the number of seconds left until the raid happens, only sent in v1 events
+	RemainingDurationSeconds int `json:"remaining_duration_seconds"`
+}
+
+// Countdown returns the time left until the raid happens, regardless of which event version it came from
+func (r *Raid) Countdown() time.Duration {
+	if r.ForceRaidNowSeconds != 0 {
+		return time.Duration(r.ForceRaidNowSeconds) * time.Second
+	}
+
+	return time.Duration(r.RemainingDurationSeconds) * time.Second
+}
+
+// RaidGo is sent when the raid is executed
+type RaidGo struct {
+	Raid
+
+	RawEvent
+}
+
+// RaidUpdate is sent when a raid is started, and periodically while it counts down
+type RaidUpdate struct {
+	Raid
+
+	// Version is either 1 (raid_update) or 2 (raid_update_v2)
+	Version int
+
+	RawEvent
+}
+
+// RaidCancel is sent when the raid is cancelled
+type RaidCancel struct {
+	Raid
+
+	RawEvent
+}
+
+type outerRaidEvent struct {
+	Type string `json:"type"`
+	Raid Raid   `json:"raid"`
+}
+
+// parseRaidEvent parses any message sent on the raid topic
+// The returned value is one of *RaidGo, *RaidUpdate or *RaidCancel
+// Returns nil without an error for message types we don't handle
+func parseRaidEvent(bytes []byte) (interface{}, error) {
+	data := &outerRaidEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newRawEvent(bytes, data)
+
+	switch data.Type {
+	case raidMessageTypeGo:
+		return &RaidGo{Raid: data.Raid, RawEvent: raw}, nil
+	case raidMessageTypeUpdate:
+		return &RaidUpdate{Raid: data.Raid, Version: 1, RawEvent: raw}, nil
+	case raidMessageTypeUpdateV2:
+		return &RaidUpdate{Raid: data.Raid, Version: 2, RawEvent: raw}, nil
+	case raidMessageTypeCancel:
+		return &RaidCancel{Raid: data.Raid, RawEvent: raw}, nil
+	}
+
+	return nil, nil
+}
+
+func parseChannelIDFromRaidTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from raid topic")
+	}
+
+	return parts[1], nil
+}
+
+func isRaidEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, raidEventTopicPrefix)
+}
+
+// RaidEventTopic returns a properly formatted raid event topic string with the given channel ID argument
+func RaidEventTopic(channelID string) string {
+	const f = `raid.%s`
+	return fmt.Sprintf(f, channelID)
+}
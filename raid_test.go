@@ -0,0 +1,157 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseRaidEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	raid := Raid{
+		ID:                 "e4c6c0d8-8b0b-4b0b-8b0b-6b4b4b0b8b0b",
+		CreatorID:          "11148817",
+		SourceID:           "11148817",
+		TargetID:           "22484632",
+		TargetLogin:        "forsen",
+		TargetDisplayName:  "forsen",
+		TargetProfileImage: "https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png",
+		ViewerCount:        420,
+	}
+
+	raidV2 := raid
+	raidV2.TransitionJitterSeconds = 5
+	raidV2.ForceRaidNowSeconds = 90
+
+	raidV1 := raid
+	raidV1.RemainingDurationSeconds = 84
+
+	testCases := []testCase{
+		{
+			label:            "Update v2",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{\"type\":\"raid_update_v2\",\"raid\":{\"id\":\"e4c6c0d8-8b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"creator_id\":\"11148817\",\"source_id\":\"11148817\",\"target_id\":\"22484632\",\"target_login\":\"forsen\",\"target_display_name\":\"forsen\",\"target_profile_image\":\"https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png\",\"transition_jitter_seconds\":5,\"force_raid_now_seconds\":90,\"viewer_count\":420}}"}}`,
+			isValidMsg:       true,
+			expected:         &RaidUpdate{Raid: raidV2, Version: 2},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Update v1",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{\"type\":\"raid_update\",\"raid\":{\"id\":\"e4c6c0d8-8b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"creator_id\":\"11148817\",\"source_id\":\"11148817\",\"target_id\":\"22484632\",\"target_login\":\"forsen\",\"target_display_name\":\"forsen\",\"target_profile_image\":\"https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png\",\"remaining_duration_seconds\":84,\"viewer_count\":420}}"}}`,
+			isValidMsg:       true,
+			expected:         &RaidUpdate{Raid: raidV1, Version: 1},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Go",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{\"type\":\"raid_go_v2\",\"raid\":{\"id\":\"e4c6c0d8-8b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"creator_id\":\"11148817\",\"source_id\":\"11148817\",\"target_id\":\"22484632\",\"target_login\":\"forsen\",\"target_display_name\":\"forsen\",\"target_profile_image\":\"https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png\",\"transition_jitter_seconds\":5,\"force_raid_now_seconds\":90,\"viewer_count\":420}}"}}`,
+			isValidMsg:       true,
+			expected:         &RaidGo{Raid: raidV2},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Cancel",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{\"type\":\"raid_cancel_v2\",\"raid\":{\"id\":\"e4c6c0d8-8b0b-4b0b-8b0b-6b4b4b0b8b0b\",\"creator_id\":\"11148817\",\"source_id\":\"11148817\",\"target_id\":\"22484632\",\"target_login\":\"forsen\",\"target_display_name\":\"forsen\",\"target_profile_image\":\"https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png\",\"transition_jitter_seconds\":5,\"force_raid_now_seconds\":90,\"viewer_count\":420}}"}}`,
+			isValidMsg:       true,
+			expected:         &RaidCancel{Raid: raidV2},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{\"type\":\"raid_forsen\",\"raid\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"raid.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isRaidEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseRaidEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestRaidCountdown(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert((&Raid{ForceRaidNowSeconds: 90}).Countdown(), qt.Equals, 90*time.Second)
+	c.Assert((&Raid{RemainingDurationSeconds: 84}).Countdown(), qt.Equals, 84*time.Second)
+	c.Assert((&Raid{}).Countdown(), qt.Equals, time.Duration(0))
+}
+
+func TestParseRaidTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label             string
+		inputTopic        string
+		expectedChannelID string
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			label:             "Standard",
+			inputTopic:        RaidEventTopic("456"),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Malformed",
+			inputTopic:        "raid",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from raid topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actualChannelID, err := parseChannelIDFromRaidTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(actualChannelID, qt.Equals, testCase.expectedChannelID)
+		})
+	}
+}
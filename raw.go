@@ -0,0 +1,184 @@
+package twitchpubsub
+
+// Helper functions and structures for keeping the raw JSON of parsed events
+// This lets users read fields Twitch added before this library knows about them
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RawEvent holds the raw JSON an event was parsed from, and is embedded in every parsed event
+type RawEvent struct {
+	// Raw is the inner message JSON as sent by Twitch
+	Raw json.RawMessage `json:"-"`
+
+	// UnknownFields maps the path of each field in Raw that the event did not recognize to its raw value, e.g. "data.new_field"
+	// Paths into arrays contain the element index, e.g. "data.fragments.0.new_field"
+	// UnknownFields is nil if every field was recognized
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// RawDataEvent is implemented by every parsed event, e.g. for reporting unknown fields without a type switch
+type RawDataEvent interface {
+	RawData() *RawEvent
+}
+
+// RawData returns the RawEvent embedded in the event
+func (r *RawEvent) RawData() *RawEvent {
+	return r
+}
+
+// clone returns a deep copy of the RawEvent, so it shares no slices or maps with r
+func (r RawEvent) clone() RawEvent {
+	if r.Raw != nil {
+		r.Raw = append(json.RawMessage{}, r.Raw...)
+	}
+	if r.UnknownFields != nil {
+		unknownFields := make(map[string]json.RawMessage, len(r.UnknownFields))
+		for path, value := range r.UnknownFields {
+			unknownFields[path] = append(json.RawMessage{}, value...)
+		}
+		r.UnknownFields = unknownFields
+	}
+
+	return r
+}
+
+// newRawEvent returns a RawEvent for bytes, which were unmarshaled into v
+func newRawEvent(bytes []byte, v interface{}) RawEvent {
+	r := RawEvent{
+		Raw: append(json.RawMessage(nil), bytes...),
+	}
+	r.addUnknownFields("", bytes, v)
+
+	return r
+}
+
+// addUnknownFields records the fields of bytes that v does not recognize, with their paths placed under prefix
+// This is used for parts of the message that are unmarshaled separately, e.g. a json.RawMessage data field
+func (r *RawEvent) addUnknownFields(prefix string, bytes []byte, v interface{}) {
+	unknown := map[string]json.RawMessage{}
+	collectUnknownFields(bytes, reflect.TypeOf(v), prefix, unknown)
+	if len(unknown) == 0 {
+		return
+	}
+
+	if r.UnknownFields == nil {
+		r.UnknownFields = unknown
+		return
+	}
+
+	for path, value := range unknown {
+		r.UnknownFields[path] = value
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func joinFieldPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+// collectUnknownFields walks raw alongside t, recording every object key t has no field for
+// Types with their own UnmarshalJSON, e.g. time.Time or json.RawMessage, are treated as fully recognized
+func collectUnknownFields(raw json.RawMessage, t reflect.Type, prefix string, unknown map[string]json.RawMessage) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return
+		}
+
+		fields := jsonFields(t)
+		for key, value := range object {
+			fieldType, ok := lookupJSONField(fields, key)
+			if !ok {
+				unknown[joinFieldPath(prefix, key)] = value
+				continue
+			}
+
+			collectUnknownFields(value, fieldType, joinFieldPath(prefix, key), unknown)
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return
+		}
+
+		for i, element := range elements {
+			collectUnknownFields(element, t.Elem(), joinFieldPath(prefix, strconv.Itoa(i)), unknown)
+		}
+	}
+}
+
+// jsonFields returns the type of each field encoding/json would unmarshal into for t, keyed by JSON name
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// lookupJSONField finds the field for key the same way encoding/json does, preferring an exact match over a case insensitive one
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if fieldType, ok := fields[key]; ok {
+		return fieldType, true
+	}
+
+	for name, fieldType := range fields {
+		if strings.EqualFold(name, key) {
+			return fieldType, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,161 @@
+package twitchpubsub
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var (
+	_ RawDataEvent = &AutoModQueueEvent{}
+	_ RawDataEvent = &BitsEvent{}
+	_ RawDataEvent = &BitsBadgeUnlockEvent{}
+	_ RawDataEvent = &BroadcastSettingsUpdate{}
+	_ RawDataEvent = &CharityDonationEvent{}
+	_ RawDataEvent = &ChatRoomUpdate{}
+	_ RawDataEvent = &ChatroomsUserModerationAction{}
+	_ RawDataEvent = &CommunityPointsEarned{}
+	_ RawDataEvent = &CommunityPointsSpent{}
+	_ RawDataEvent = &CommunityPointsClaimAvailable{}
+	_ RawDataEvent = &GoalCreated{}
+	_ RawDataEvent = &GoalUpdated{}
+	_ RawDataEvent = &GoalAchieved{}
+	_ RawDataEvent = &GoalEnded{}
+	_ RawDataEvent = &ExtensionMessage{}
+	_ RawDataEvent = &LeaderboardUpdate{}
+	_ RawDataEvent = &LowTrustUserEvent{}
+	_ RawDataEvent = &ModerationAction{}
+	_ RawDataEvent = &RoleChangeEvent{}
+	_ RawDataEvent = &ChannelTermsEvent{}
+	_ RawDataEvent = &UnbanRequestActionEvent{}
+	_ RawDataEvent = &PinCreated{}
+	_ RawDataEvent = &PinUpdated{}
+	_ RawDataEvent = &PinDeleted{}
+	_ RawDataEvent = &PointsEvent{}
+	_ RawDataEvent = &RaidGo{}
+	_ RawDataEvent = &RaidUpdate{}
+	_ RawDataEvent = &RaidCancel{}
+	_ RawDataEvent = &ShoutoutCreate{}
+	_ RawDataEvent = &ShoutoutReceived{}
+	_ RawDataEvent = &MysteryGiftEvent{}
+	_ RawDataEvent = &SubscribeEvent{}
+	_ RawDataEvent = &UserSubscribeEvent{}
+	_ RawDataEvent = &UnbanRequestCreate{}
+	_ RawDataEvent = &UnbanRequestUpdate{}
+	_ RawDataEvent = &UserModerationNotificationEvent{}
+	_ RawDataEvent = &WhisperEvent{}
+	_ RawDataEvent = &WhisperSentEvent{}
+	_ RawDataEvent = &WhisperThreadEvent{}
+)
+
+func TestRawEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label         string
+		input         string
+		parse         func([]byte) (interface{}, error)
+		expectedPaths map[string]string
+	}
+
+	testCases := []testCase{
+		{
+			label: "All fields known",
+			input: `{"user_name":"pajlada","user_id":"11148817","sub_message":{"message":"","emotes":null},"benefit_end_month":0,"multi_month_duration":0}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseSubscribeEvent(b)
+			},
+			expectedPaths: nil,
+		},
+		{
+			label: "Unknown top level and nested fields",
+			input: `{"user_name":"pajlada","sub_message":{"message":"Kappa","emotes":[{"start":0,"end":4,"id":"25","set_id":"0"}],"is_highlighted":true},"months_prepaid":3}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseSubscribeEvent(b)
+			},
+			expectedPaths: map[string]string{
+				"months_prepaid":              `3`,
+				"sub_message.is_highlighted":  `true`,
+				"sub_message.emotes.0.set_id": `"0"`,
+			},
+		},
+		{
+			label: "Unknown field in wrapped data",
+			input: `{"data":{"user_name":"pajlada","bits_used":100,"badge_tier":1},"version":"1.0","message_type":"bits_event","message_id":"8145728a-2a3b-5f4c-9c2e-1f1b2f3d0c4b"}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseBitsEvent(b)
+			},
+			expectedPaths: map[string]string{
+				"data.badge_tier": `1`,
+			},
+		},
+		{
+			label: "Envelope type field is known",
+			input: `{"type":"automod_caught_message","data":{"content_classification":{"category":"aggressive","level":1},"status":"PENDING"}}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseAutoModQueueEvent(b)
+			},
+			expectedPaths: nil,
+		},
+		{
+			label: "Unknown field in separately parsed data",
+			input: `{"type":"moderation_action","data":{"moderation_action":"ban","args":["forsen"],"created_by":"pajlada","from_automod":false}}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseModerationMessage(b)
+			},
+			expectedPaths: map[string]string{
+				"data.from_automod": `false`,
+			},
+		},
+		{
+			label: "All role change fields known",
+			input: `{"type":"moderator_added","data":{"channel_id":"11148817","target_user_id":"129546453","moderation_action":"mod","target_user_login":"nerixyz","created_by_user_id":"11148817","created_by":"pajlada"}}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseModerationMessage(b)
+			},
+			expectedPaths: nil,
+		},
+		{
+			label: "Unknown field in whisper data_object",
+			input: `{"type":"whisper_received","data":"{}","data_object":{"id":1,"body":"forsen","is_first_whisper":true}}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseWhisperEvent(b)
+			},
+			expectedPaths: map[string]string{
+				"data_object.is_first_whisper": `true`,
+			},
+		},
+		{
+			label: "Unknown field in community points data",
+			input: `{"type":"points-spent","data":{"timestamp":"2023-06-11T10:44:06Z","balance":{"user_id":"1","channel_id":"2","balance":100},"reason":"REDEMPTION"}}`,
+			parse: func(b []byte) (interface{}, error) {
+				return parseCommunityPointsUserEvent(b)
+			},
+			expectedPaths: map[string]string{
+				"data.reason": `"REDEMPTION"`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actual, err := testCase.parse([]byte(testCase.input))
+			c.Assert(err, qt.IsNil)
+
+			raw := actual.(RawDataEvent).RawData()
+			c.Assert(string(raw.Raw), qt.Equals, testCase.input)
+
+			if testCase.expectedPaths == nil {
+				c.Assert(raw.UnknownFields, qt.IsNil)
+				return
+			}
+
+			expected := map[string]json.RawMessage{}
+			for path, value := range testCase.expectedPaths {
+				expected[path] = json.RawMessage(value)
+			}
+			c.Assert(raw.UnknownFields, qt.DeepEquals, expected)
+		})
+	}
+}
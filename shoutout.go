@@ -0,0 +1,109 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch shoutout events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const shoutoutEventTopicPrefix = "shoutout."
+
+// Known values of the outer message type on the shoutout topic
+const (
+	shoutoutMessageTypeCreate  = "create"
+	shoutoutMessageTypeReceive = "receive"
+)
+
+// Shoutout describes a shoutout from one channel to another, shared by all shoutout events
+type Shoutout struct {
+	ShoutoutID string `json:"shoutoutID"`
+
+	BroadcasterUserID string `json:"broadcasterUserID"`
+
+	// SourceUserID & SourceLogin describe the channel that gave the shoutout
+	SourceUserID string `json:"sourceUserID"`
+	SourceLogin  string `json:"sourceLogin"`
+
+	// Target* describe the channel that received the shoutout
+	TargetUserID              string `json:"targetUserID"`
+	TargetLogin               string `json:"targetLogin"`
+	TargetUserDisplayName     string `json:"targetUserDisplayName"`
+	TargetUserProfileImageURL string `json:"targetUserProfileImageURL"`
+	TargetUserPrimaryColorHex string `json:"targetUserPrimaryColorHex"`
+	TargetUserCTAInfo         string `json:"targetUserCTAInfo"`
+
+	// TargetUserLastGameName is the name of the game the target channel last streamed
+	TargetUserLastGameName string `json:"targetUserLastGameName"`
+
+	// ViewerCount is the number of viewers who saw the shoutout
+	ViewerCount int `json:"viewerCount"`
+
+	// CooldownEndsAt is when the source channel can give another shoutout
+	CooldownEndsAt time.Time `json:"cooldownEndsAt"`
+	// TargetCooldownEndsAt is when the source channel can shout out the same target again
+	TargetCooldownEndsAt time.Time `json:"targetCooldownEndsAt"`
+}
+
+// ShoutoutCreate is sent when the channel gives a shoutout to another channel
+type ShoutoutCreate struct {
+	Shoutout
+
+	RawEvent
+}
+
+// ShoutoutReceived is sent when the channel receives a shoutout from another channel
+type ShoutoutReceived struct {
+	Shoutout
+
+	RawEvent
+}
+
+type outerShoutoutEvent struct {
+	Type string   `json:"type"`
+	Data Shoutout `json:"data"`
+}
+
+// parseShoutoutEvent parses any message sent on the shoutout topic
+// The returned value is one of *ShoutoutCreate or *ShoutoutReceived
+// Returns nil without an error for message types we don't handle
+func parseShoutoutEvent(bytes []byte) (interface{}, error) {
+	data := &outerShoutoutEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newRawEvent(bytes, data)
+
+	switch data.Type {
+	case shoutoutMessageTypeCreate:
+		return &ShoutoutCreate{Shoutout: data.Data, RawEvent: raw}, nil
+	case shoutoutMessageTypeReceive:
+		return &ShoutoutReceived{Shoutout: data.Data, RawEvent: raw}, nil
+	}
+
+	return nil, nil
+}
+
+func parseChannelIDFromShoutoutTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from shoutout topic")
+	}
+
+	return parts[1], nil
+}
+
+func isShoutoutEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, shoutoutEventTopicPrefix)
+}
+
+// ShoutoutEventTopic returns a properly formatted shoutout event topic string with the given channel ID argument
+func ShoutoutEventTopic(channelID string) string {
+	const f = `shoutout.%s`
+	return fmt.Sprintf(f, channelID)
+}
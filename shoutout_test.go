@@ -0,0 +1,107 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseShoutoutEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	shoutout := Shoutout{
+		ShoutoutID:                "6a0a8b0b-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+		BroadcasterUserID:         "11148817",
+		SourceUserID:              "11148817",
+		SourceLogin:               "pajlada",
+		TargetUserID:              "22484632",
+		TargetLogin:               "forsen",
+		TargetUserDisplayName:     "forsen",
+		TargetUserProfileImageURL: "https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png",
+		TargetUserPrimaryColorHex: "FF0000",
+		TargetUserCTAInfo:         "forsen",
+		TargetUserLastGameName:    "Minecraft",
+		ViewerCount:               420,
+		CooldownEndsAt:            time.Date(2023, time.June, 17, 15, 6, 31, 0, time.UTC),
+		TargetCooldownEndsAt:      time.Date(2023, time.June, 17, 16, 4, 31, 0, time.UTC),
+	}
+
+	const data = `{\"shoutoutID\":\"6a0a8b0b-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"broadcasterUserID\":\"11148817\",\"sourceUserID\":\"11148817\",\"sourceLogin\":\"pajlada\",\"targetUserID\":\"22484632\",\"targetLogin\":\"forsen\",\"targetUserDisplayName\":\"forsen\",\"targetUserProfileImageURL\":\"https://static-cdn.jtvnw.net/jtv_user_pictures/forsen-profile_image-48b43e1e4f54b5c8-70x70.png\",\"targetUserPrimaryColorHex\":\"FF0000\",\"targetUserCTAInfo\":\"forsen\",\"targetUserLastGameName\":\"Minecraft\",\"viewerCount\":420,\"cooldownEndsAt\":\"2023-06-17T15:06:31Z\",\"targetCooldownEndsAt\":\"2023-06-17T16:04:31Z\"}`
+
+	testCases := []testCase{
+		{
+			label:            "Create",
+			input:            `{"type":"MESSAGE","data":{"topic":"shoutout.11148817","message":"{\"type\":\"create\",\"data\":` + data + `}"}}`,
+			isValidMsg:       true,
+			expected:         &ShoutoutCreate{Shoutout: shoutout},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Receive",
+			input:            `{"type":"MESSAGE","data":{"topic":"shoutout.22484632","message":"{\"type\":\"receive\",\"data\":` + data + `}"}}`,
+			isValidMsg:       true,
+			expected:         &ShoutoutReceived{Shoutout: shoutout},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"shoutout.11148817","message":"{\"type\":\"forsen\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"shoutout.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isShoutoutEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseShoutoutEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParseShoutoutTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromShoutoutTopic(ShoutoutEventTopic("456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromShoutoutTopic("shoutout")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from shoutout topic")
+}
@@ -0,0 +1,79 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch mystery gift (sub bomb) events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const subGiftsEventTopicPrefix = "channel-sub-gifts-v1."
+
+// MysteryGiftEvent describes a user starting to gift a number of subscriptions to random viewers at once, coming from Twitch's PubSub servers
+// The individual gift subscriptions are still sent as SubscribeEvents on the subscribe topic
+type MysteryGiftEvent struct {
+	// Type is the kind of gift event, e.g. "mystery-gift-purchase"
+	Type string `json:"type"`
+
+	// ChannelID is the channel the subscriptions were gifted in
+	ChannelID string `json:"channel_id"`
+
+	// UserID is the ID of the gifter
+	// Can be empty if it was an anonymous gift
+	UserID string `json:"user_id"`
+
+	// UserName is the login name of the gifter
+	// Can be empty if it was an anonymous gift
+	UserName string `json:"user_name"`
+
+	// DisplayName is the display name of the gifter
+	// Can be empty if it was an anonymous gift
+	DisplayName string `json:"display_name"`
+
+	// Count is the number of subscriptions gifted
+	Count int `json:"count"`
+
+	// Tier is the subscription plan ID of the gifted subscriptions (e.g. 1000, 2000, 3000)
+	Tier string `json:"tier"`
+
+	// UUID uniquely identifies this gift event
+	UUID string `json:"uuid"`
+
+	// OriginID links this gift event to the individual gift subscriptions
+	OriginID string `json:"origin_id"`
+
+	RawEvent
+}
+
+func parseMysteryGiftEvent(bytes []byte) (*MysteryGiftEvent, error) {
+	data := &MysteryGiftEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+func parseChannelIDFromSubGiftsTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse channel ID from sub gifts topic")
+	}
+
+	return parts[1], nil
+}
+
+func isSubGiftsEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, subGiftsEventTopicPrefix)
+}
+
+// SubGiftsEventTopic returns a properly formatted sub gifts event topic string with the given channel ID argument
+func SubGiftsEventTopic(channelID string) string {
+	const f = `channel-sub-gifts-v1.%s`
+	return fmt.Sprintf(f, channelID)
+}
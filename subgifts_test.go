@@ -0,0 +1,103 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseMysteryGiftEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *MysteryGiftEvent
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Gift bomb",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-sub-gifts-v1.11148817","message":"{\"count\":50,\"tier\":\"1000\",\"user_id\":\"11148817\",\"channel_id\":\"11148817\",\"uuid\":\"a7f6fe0c-47c5-4d5e-8d9a-6b8f3e2c1d0a\",\"type\":\"mystery-gift-purchase\",\"user_name\":\"pajlada\",\"display_name\":\"pajlada\",\"origin_id\":\"6f 2b 8b 8a 4a 5f 3d 0e 9c 7e\"}"}}`,
+			isValidMsg: true,
+			expected: &MysteryGiftEvent{
+				Type:        "mystery-gift-purchase",
+				ChannelID:   "11148817",
+				UserID:      "11148817",
+				UserName:    "pajlada",
+				DisplayName: "pajlada",
+				Count:       50,
+				Tier:        "1000",
+				UUID:        "a7f6fe0c-47c5-4d5e-8d9a-6b8f3e2c1d0a",
+				OriginID:    "6f 2b 8b 8a 4a 5f 3d 0e 9c 7e",
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Anonymous gift bomb",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-sub-gifts-v1.11148817","message":"{\"count\":5,\"tier\":\"2000\",\"channel_id\":\"11148817\",\"uuid\":\"b3c2d1e0-47c5-4d5e-8d9a-6b8f3e2c1d0a\",\"type\":\"mystery-gift-purchase\"}"}}`,
+			isValidMsg: true,
+			expected: &MysteryGiftEvent{
+				Type:      "mystery-gift-purchase",
+				ChannelID: "11148817",
+				Count:     5,
+				Tier:      "2000",
+				UUID:      "b3c2d1e0-47c5-4d5e-8d9a-6b8f3e2c1d0a",
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-sub-gifts-v1.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isSubGiftsEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseMysteryGiftEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCreateSubGiftsTopic(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(SubGiftsEventTopic("456"), qt.Equals, "channel-sub-gifts-v1.456")
+	c.Assert(SubGiftsEventTopic(""), qt.Equals, "channel-sub-gifts-v1.")
+}
+
+func TestParseSubGiftsTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromSubGiftsTopic("channel-sub-gifts-v1.456")
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromSubGiftsTopic("channel-sub-gifts-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from sub gifts topic")
+}
@@ -13,6 +13,14 @@ import (
 
 const subscribeEventTopicPrefix = "channel-subscribe-events-v1."
 
+// Known values of SubscribeEvent.Context
+const (
+	SubscribeContextSub         = "sub"
+	SubscribeContextResub       = "resub"
+	SubscribeContextSubGift     = "subgift"
+	SubscribeContextAnonSubGift = "anonsubgift"
+)
+
 // SubscribeEvent describes an incoming subscription event on Twitch
 type SubscribeEvent struct {
 	// ChannelID is the channel that has been subscribed or subgifted to
@@ -66,7 +74,15 @@ type SubscribeEvent struct {
 	// IsGift denotes whether this subscription was caused by a gift subscription
 	IsGift bool `json:"is_gift"`
 
+	// MultiMonthDuration is the number of months the subscription was bought or gifted for at once
+	MultiMonthDuration int `json:"multi_month_duration"`
+
+	// BenefitEndMonth is the month the benefits of a multi-month subscription end, or 0 if not applicable
+	BenefitEndMonth int `json:"benefit_end_month"`
+
 	SubMessage SubMessage `json:"sub_message"`
+
+	RawEvent
 }
 
 type Emotes struct {
@@ -87,6 +103,8 @@ func parseSubscribeEvent(bytes []byte) (*SubscribeEvent, error) {
 		return nil, err
 	}
 
+	data.RawEvent = newRawEvent(bytes, data)
+
 	return data, nil
 }
 
@@ -163,6 +163,7 @@ func TestParseSubscribeEvent(t *testing.T) {
 				StreakMonths:         0,
 				Context:              "subgift",
 				IsGift:               true,
+				MultiMonthDuration:   1,
 				SubMessage: SubMessage{
 					Message: "",
 					Emotes:  nil,
@@ -191,6 +192,7 @@ func TestParseSubscribeEvent(t *testing.T) {
 				StreakMonths:         0,
 				Context:              "anonsubgift",
 				IsGift:               true,
+				MultiMonthDuration:   1,
 				SubMessage: SubMessage{
 					Message: "",
 					Emotes:  nil,
@@ -213,7 +215,7 @@ func TestParseSubscribeEvent(t *testing.T) {
 				actual, err := parseSubscribeEvent([]byte(innerMessageBytes))
 
 				c.Assert(err, qt.Equals, testCase.expectedErr)
-				c.Assert(actual, qt.DeepEquals, testCase.expected)
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
 			}
 		})
 	}
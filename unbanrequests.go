@@ -0,0 +1,180 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch unban request events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const unbanRequestsEventTopicPrefix = "channel-unban-requests."
+
+// Known values of the outer message type on the unban requests topic
+const (
+	unbanRequestsMessageTypeCreate = "create_unban_request"
+	unbanRequestsMessageTypeUpdate = "update_unban_request"
+)
+
+// Known values of UnbanRequest.Status
+const (
+	UnbanRequestStatusPending      = "PENDING"
+	UnbanRequestStatusApproved     = "APPROVED"
+	UnbanRequestStatusDenied       = "DENIED"
+	UnbanRequestStatusAcknowledged = "ACKNOWLEDGED"
+	UnbanRequestStatusCanceled     = "CANCELED"
+)
+
+// UnbanRequest describes a banned user's request to be unbanned, shared by all unban request events
+type UnbanRequest struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+
+	RequesterID          string `json:"requester_id"`
+	RequesterLogin       string `json:"requester_login"`
+	RequesterDisplayName string `json:"requester_display_name"`
+
+	// Text is the message the requester wrote
+	Text string `json:"text"`
+
+	// Status is one of the UnbanRequestStatus* constants
+	Status string `json:"status"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Resolver* & Resolution* are empty until the request has been resolved
+	ResolverID          string     `json:"resolver_id"`
+	ResolverLogin       string     `json:"resolver_login"`
+	ResolverDisplayName string     `json:"resolver_display_name"`
+	ResolutionText      string     `json:"resolution_text"`
+	ResolvedAt          *time.Time `json:"resolved_at"`
+}
+
+// UnbanRequestCreate is sent when a banned user creates an unban request
+type UnbanRequestCreate struct {
+	UnbanRequest
+
+	RawEvent
+}
+
+// UnbanRequestUpdate is sent when an unban request is resolved by a moderator or canceled by the requester
+type UnbanRequestUpdate struct {
+	UnbanRequest
+
+	RawEvent
+}
+
+type outerUnbanRequestEvent struct {
+	Type string       `json:"type"`
+	Data UnbanRequest `json:"data"`
+}
+
+// parseUnbanRequestEvent parses any message sent on the unban requests topic
+// The returned value is one of *UnbanRequestCreate or *UnbanRequestUpdate
+// Returns nil without an error for message types we don't handle
+func parseUnbanRequestEvent(bytes []byte) (interface{}, error) {
+	data := &outerUnbanRequestEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := newRawEvent(bytes, data)
+
+	switch data.Type {
+	case unbanRequestsMessageTypeCreate:
+		return &UnbanRequestCreate{UnbanRequest: data.Data, RawEvent: raw}, nil
+	case unbanRequestsMessageTypeUpdate:
+		return &UnbanRequestUpdate{UnbanRequest: data.Data, RawEvent: raw}, nil
+	}
+
+	return nil, nil
+}
+
+func parseChannelIDFromUnbanRequestsTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 {
+		return "", errors.New("unable to parse channel ID from unban requests topic")
+	}
+
+	return parts[2], nil
+}
+
+func isUnbanRequestsEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, unbanRequestsEventTopicPrefix)
+}
+
+// UnbanRequestsEventTopic returns a properly formatted unban requests event topic string with the given moderator and channel ID arguments
+func UnbanRequestsEventTopic(modID, channelID string) string {
+	const f = `channel-unban-requests.%s.%s`
+	return fmt.Sprintf(f, modID, channelID)
+}
+
+// unbanRequestQueue keeps the pending unban requests for each channel, in the order they were created
+type unbanRequestQueue struct {
+	mutex   sync.Mutex
+	pending map[string][]UnbanRequest
+}
+
+func newUnbanRequestQueue() *unbanRequestQueue {
+	return &unbanRequestQueue{
+		pending: make(map[string][]UnbanRequest),
+	}
+}
+
+func (q *unbanRequestQueue) created(channelID string, event *UnbanRequestCreate) {
+	if event.Status != UnbanRequestStatusPending {
+		return
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, request := range q.pending[channelID] {
+		if request.ID == event.ID {
+			return
+		}
+	}
+
+	q.pending[channelID] = append(q.pending[channelID], event.UnbanRequest)
+}
+
+func (q *unbanRequestQueue) updated(channelID string, event *UnbanRequestUpdate) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	requests := q.pending[channelID]
+	for i, request := range requests {
+		if request.ID != event.ID {
+			continue
+		}
+
+		if event.Status == UnbanRequestStatusPending {
+			requests[i] = event.UnbanRequest
+			return
+		}
+
+		q.pending[channelID] = append(requests[:i:i], requests[i+1:]...)
+		if len(q.pending[channelID]) == 0 {
+			delete(q.pending, channelID)
+		}
+		return
+	}
+}
+
+func (q *unbanRequestQueue) get(channelID string) []UnbanRequest {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	requests := q.pending[channelID]
+	if len(requests) == 0 {
+		return nil
+	}
+
+	result := make([]UnbanRequest, len(requests))
+	copy(result, requests)
+	return result
+}
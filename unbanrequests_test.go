@@ -0,0 +1,157 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseUnbanRequestEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	resolvedAt := time.Date(2023, time.June, 17, 15, 10, 0, 0, time.UTC)
+
+	testCases := []testCase{
+		{
+			label:      "Create",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-unban-requests.11148817.11148817","message":"{\"type\":\"create_unban_request\",\"data\":{\"id\":\"c8f0b0b0-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channel_id\":\"11148817\",\"requester_id\":\"133077169\",\"requester_login\":\"slurps\",\"requester_display_name\":\"slurps\",\"text\":\"please unban me\",\"status\":\"PENDING\",\"created_at\":\"2023-06-17T15:04:31Z\",\"resolver_id\":\"\",\"resolver_login\":\"\",\"resolution_text\":\"\",\"resolved_at\":null}}"}}`,
+			isValidMsg: true,
+			expected: &UnbanRequestCreate{
+				UnbanRequest: UnbanRequest{
+					ID:                   "c8f0b0b0-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+					ChannelID:            "11148817",
+					RequesterID:          "133077169",
+					RequesterLogin:       "slurps",
+					RequesterDisplayName: "slurps",
+					Text:                 "please unban me",
+					Status:               UnbanRequestStatusPending,
+					CreatedAt:            time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Update",
+			input:      `{"type":"MESSAGE","data":{"topic":"channel-unban-requests.11148817.11148817","message":"{\"type\":\"update_unban_request\",\"data\":{\"id\":\"c8f0b0b0-4b0b-8b0b-6b4b-4b0b8b0b6b4b\",\"channel_id\":\"11148817\",\"requester_id\":\"133077169\",\"requester_login\":\"slurps\",\"requester_display_name\":\"slurps\",\"text\":\"please unban me\",\"status\":\"DENIED\",\"created_at\":\"2023-06-17T15:04:31Z\",\"resolver_id\":\"11148817\",\"resolver_login\":\"pajlada\",\"resolver_display_name\":\"pajlada\",\"resolution_text\":\"no\",\"resolved_at\":\"2023-06-17T15:10:00Z\"}}"}}`,
+			isValidMsg: true,
+			expected: &UnbanRequestUpdate{
+				UnbanRequest: UnbanRequest{
+					ID:                   "c8f0b0b0-4b0b-8b0b-6b4b-4b0b8b0b6b4b",
+					ChannelID:            "11148817",
+					RequesterID:          "133077169",
+					RequesterLogin:       "slurps",
+					RequesterDisplayName: "slurps",
+					Text:                 "please unban me",
+					Status:               UnbanRequestStatusDenied,
+					CreatedAt:            time.Date(2023, time.June, 17, 15, 4, 31, 0, time.UTC),
+					ResolverID:           "11148817",
+					ResolverLogin:        "pajlada",
+					ResolverDisplayName:  "pajlada",
+					ResolutionText:       "no",
+					ResolvedAt:           &resolvedAt,
+				},
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Unhandled type",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-unban-requests.11148817.11148817","message":"{\"type\":\"forsen\",\"data\":{}}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"channel-unban-requests.11148817.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isUnbanRequestsEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseUnbanRequestEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestUnbanRequestQueue(t *testing.T) {
+	c := qt.New(t)
+
+	queue := newUnbanRequestQueue()
+	c.Assert(queue.get("11148817"), qt.IsNil)
+
+	request := func(id, status string) UnbanRequest {
+		return UnbanRequest{ID: id, ChannelID: "11148817", Status: status}
+	}
+
+	queue.created("11148817", &UnbanRequestCreate{UnbanRequest: request("1", UnbanRequestStatusPending)})
+	queue.created("11148817", &UnbanRequestCreate{UnbanRequest: request("2", UnbanRequestStatusPending)})
+	queue.created("11148817", &UnbanRequestCreate{UnbanRequest: request("3", UnbanRequestStatusPending)})
+	// Duplicates are ignored
+	queue.created("11148817", &UnbanRequestCreate{UnbanRequest: request("2", UnbanRequestStatusPending)})
+
+	c.Assert(queue.get("11148817"), qt.DeepEquals, []UnbanRequest{
+		request("1", UnbanRequestStatusPending),
+		request("2", UnbanRequestStatusPending),
+		request("3", UnbanRequestStatusPending),
+	})
+
+	queue.updated("11148817", &UnbanRequestUpdate{UnbanRequest: request("2", UnbanRequestStatusApproved)})
+	queue.updated("11148817", &UnbanRequestUpdate{UnbanRequest: request("4", UnbanRequestStatusDenied)})
+
+	pending := queue.get("11148817")
+	c.Assert(pending, qt.DeepEquals, []UnbanRequest{
+		request("1", UnbanRequestStatusPending),
+		request("3", UnbanRequestStatusPending),
+	})
+
+	// The returned slice is a copy
+	pending[0].Status = UnbanRequestStatusDenied
+	c.Assert(queue.get("11148817")[0].Status, qt.Equals, UnbanRequestStatusPending)
+
+	queue.updated("11148817", &UnbanRequestUpdate{UnbanRequest: request("1", UnbanRequestStatusCanceled)})
+	queue.updated("11148817", &UnbanRequestUpdate{UnbanRequest: request("3", UnbanRequestStatusDenied)})
+	c.Assert(queue.get("11148817"), qt.IsNil)
+}
+
+func TestParseUnbanRequestsTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	channelID, err := parseChannelIDFromUnbanRequestsTopic(UnbanRequestsEventTopic("123", "456"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(channelID, qt.Equals, "456")
+
+	_, err = parseChannelIDFromUnbanRequestsTopic("channel-unban-requests.123")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from unban requests topic")
+}
@@ -0,0 +1,275 @@
+package twitchpubsub
+
+// Helper functions and structures for describing the user behind an event
+// Every topic models users its own way, these accessors convert them to a shared User without touching the original fields
+
+import "strconv"
+
+// UserID is a Twitch user ID
+// Some topics send IDs as numbers and some as strings, UserID is always the decimal string form
+type UserID string
+
+// userIDFromInt converts a numeric user ID to a UserID, treating 0 as missing
+func userIDFromInt(id int) UserID {
+	if id == 0 {
+		return ""
+	}
+
+	return UserID(strconv.Itoa(id))
+}
+
+// User describes a Twitch user
+// Fields the topic does not send are left empty
+type User struct {
+	ID          UserID
+	Login       string
+	DisplayName string
+
+	// Color is the user's chat color as a hex string, e.g. "#FF0000"
+	Color string
+}
+
+// UserEvent is implemented by every event that was caused by a user
+type UserEvent interface {
+	// Actor returns the user who caused the event
+	Actor() User
+}
+
+// Actor returns the user who sent the bits, which is empty if they cheered anonymously
+// Anonymous cheers are sent with Twitch's placeholder "ananonymouscheerer" account, which is not returned
+func (e *BitsEvent) Actor() User {
+	if e.IsAnonymous {
+		return User{}
+	}
+
+	return User{
+		ID:    UserID(e.UserID),
+		Login: e.UserName,
+	}
+}
+
+// Actor returns the user who unlocked the badge
+func (e *BitsBadgeUnlockEvent) Actor() User {
+	return User{
+		ID:    UserID(e.UserID),
+		Login: e.UserName,
+	}
+}
+
+// Actor returns the user who redeemed the reward
+func (e *PointsEvent) Actor() User {
+	return User{
+		ID:          UserID(e.User.Id),
+		Login:       e.User.User,
+		DisplayName: e.User.DisplayName,
+	}
+}
+
+// Actor returns the user who sent the whisper
+func (e *WhisperEvent) Actor() User {
+	return User{
+		ID:          userIDFromInt(e.FromID),
+		Login:       e.Tags.Login,
+		DisplayName: e.Tags.DisplayName,
+		Color:       e.Tags.Color,
+	}
+}
+
+// RecipientUser returns the user who received the whisper
+func (e *WhisperEvent) RecipientUser() User {
+	return User{
+		ID:          userIDFromInt(e.Recipient.ID),
+		Login:       e.Recipient.Username,
+		DisplayName: e.Recipient.DisplayName,
+		Color:       e.Recipient.Color,
+	}
+}
+
+// Actor returns the user who sent the message AutoMod caught
+func (e *AutoModQueueEvent) Actor() User {
+	return User{
+		ID:          UserID(e.Message.Sender.UserID),
+		Login:       e.Message.Sender.Login,
+		DisplayName: e.Message.Sender.DisplayName,
+		Color:       e.Message.Sender.ChatColor,
+	}
+}
+
+// Actor returns the user who subscribed or gifted the subscription
+func (e *SubscribeEvent) Actor() User {
+	return User{
+		ID:          UserID(e.UserID),
+		Login:       e.UserName,
+		DisplayName: e.DisplayName,
+	}
+}
+
+// RecipientUser returns the user who was gifted the subscription, which is empty if the subscription was not a gift
+func (e *SubscribeEvent) RecipientUser() User {
+	return User{
+		ID:          UserID(e.RecipientID),
+		Login:       e.RecipientUserName,
+		DisplayName: e.RecipientDisplayName,
+	}
+}
+
+// Actor returns the user who gifted the subscriptions
+func (e *MysteryGiftEvent) Actor() User {
+	return User{
+		ID:          UserID(e.UserID),
+		Login:       e.UserName,
+		DisplayName: e.DisplayName,
+	}
+}
+
+// Actor returns the user who gifted the subscriptions
+func (b *GiftBatch) Actor() User {
+	return User{
+		ID:          UserID(b.Gifter.UserID),
+		Login:       b.Gifter.UserName,
+		DisplayName: b.Gifter.DisplayName,
+	}
+}
+
+// Actor returns the moderator who performed the action
+func (a *ModerationAction) Actor() User {
+	return User{
+		ID:    UserID(a.CreatedByUserID),
+		Login: a.CreatedBy,
+	}
+}
+
+// Actor returns the user who changed the role
+func (e *RoleChangeEvent) Actor() User {
+	return User{
+		ID:    UserID(e.CreatedByUserID),
+		Login: e.CreatedBy,
+	}
+}
+
+// Actor returns the user who added or removed the term
+func (e *ChannelTermsEvent) Actor() User {
+	return User{
+		ID:    UserID(e.RequesterID),
+		Login: e.RequesterLogin,
+	}
+}
+
+// Actor returns the moderator who approved or denied the unban request
+func (e *UnbanRequestActionEvent) Actor() User {
+	return User{
+		ID:    UserID(e.CreatedByID),
+		Login: e.CreatedByLogin,
+	}
+}
+
+// Actor returns the moderator who changed the user's treatment, or the user who sent the message
+func (e *LowTrustUserEvent) Actor() User {
+	if e.TreatmentUpdate != nil {
+		return User{
+			ID:          UserID(e.TreatmentUpdate.UpdatedBy.ID),
+			Login:       e.TreatmentUpdate.UpdatedBy.Login,
+			DisplayName: e.TreatmentUpdate.UpdatedBy.DisplayName,
+		}
+	}
+
+	if e.NewMessage != nil && e.NewMessage.LowTrustUser.Sender != nil {
+		sender := e.NewMessage.LowTrustUser.Sender
+		return User{
+			ID:          UserID(sender.UserID),
+			Login:       sender.Login,
+			DisplayName: sender.DisplayName,
+			Color:       sender.ChatColor,
+		}
+	}
+
+	return User{}
+}
+
+// Actor returns the user who donated
+func (e *CharityDonationEvent) Actor() User {
+	return User{
+		ID:          UserID(e.DonorID),
+		Login:       e.DonorLogin,
+		DisplayName: e.DonorDisplayName,
+	}
+}
+
+// Actor returns the user who pinned the message
+func (e *PinCreated) Actor() User {
+	return User{
+		ID:          UserID(e.PinnedBy.ID),
+		DisplayName: e.PinnedBy.DisplayName,
+	}
+}
+
+// Actor returns the user who unpinned the message
+func (e *PinDeleted) Actor() User {
+	return User{
+		ID:          UserID(e.UnpinnedBy.ID),
+		DisplayName: e.UnpinnedBy.DisplayName,
+	}
+}
+
+// Actor returns the user who created the unban request
+func (e *UnbanRequestCreate) Actor() User {
+	return User{
+		ID:          UserID(e.RequesterID),
+		Login:       e.RequesterLogin,
+		DisplayName: e.RequesterDisplayName,
+	}
+}
+
+// Actor returns the moderator who resolved the unban request, or the requester if they canceled it
+func (e *UnbanRequestUpdate) Actor() User {
+	if e.ResolverID == "" {
+		return User{
+			ID:          UserID(e.RequesterID),
+			Login:       e.RequesterLogin,
+			DisplayName: e.RequesterDisplayName,
+		}
+	}
+
+	return User{
+		ID:          UserID(e.ResolverID),
+		Login:       e.ResolverLogin,
+		DisplayName: e.ResolverDisplayName,
+	}
+}
+
+// Actor returns the channel that gave the shoutout
+func (s *Shoutout) Actor() User {
+	return User{
+		ID:    UserID(s.SourceUserID),
+		Login: s.SourceLogin,
+	}
+}
+
+// Actor returns the user who started the raid
+// Only the user's ID is sent
+func (r *Raid) Actor() User {
+	return User{
+		ID: UserID(r.CreatorID),
+	}
+}
+
+// Actor returns the user who earned the channel points
+func (e *CommunityPointsEarned) Actor() User {
+	return User{
+		ID: UserID(e.PointGain.UserID),
+	}
+}
+
+// Actor returns the user who spent the channel points
+func (e *CommunityPointsSpent) Actor() User {
+	return User{
+		ID: UserID(e.Balance.UserID),
+	}
+}
+
+// Actor returns the user the claim is available to
+func (e *CommunityPointsClaimAvailable) Actor() User {
+	return User{
+		ID: UserID(e.Claim.UserID),
+	}
+}
@@ -0,0 +1,156 @@
+package twitchpubsub
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var (
+	_ UserEvent = &BitsEvent{}
+	_ UserEvent = &BitsBadgeUnlockEvent{}
+	_ UserEvent = &PointsEvent{}
+	_ UserEvent = &WhisperEvent{}
+	_ UserEvent = &WhisperSentEvent{}
+	_ UserEvent = &AutoModQueueEvent{}
+	_ UserEvent = &SubscribeEvent{}
+	_ UserEvent = &UserSubscribeEvent{}
+	_ UserEvent = &MysteryGiftEvent{}
+	_ UserEvent = &GiftBatch{}
+	_ UserEvent = &ModerationAction{}
+	_ UserEvent = &RoleChangeEvent{}
+	_ UserEvent = &ChannelTermsEvent{}
+	_ UserEvent = &UnbanRequestActionEvent{}
+	_ UserEvent = &LowTrustUserEvent{}
+	_ UserEvent = &CharityDonationEvent{}
+	_ UserEvent = &PinCreated{}
+	_ UserEvent = &PinDeleted{}
+	_ UserEvent = &UnbanRequestCreate{}
+	_ UserEvent = &UnbanRequestUpdate{}
+	_ UserEvent = &RaidGo{}
+	_ UserEvent = &RaidUpdate{}
+	_ UserEvent = &RaidCancel{}
+	_ UserEvent = &ShoutoutCreate{}
+	_ UserEvent = &ShoutoutReceived{}
+	_ UserEvent = &CommunityPointsEarned{}
+	_ UserEvent = &CommunityPointsSpent{}
+	_ UserEvent = &CommunityPointsClaimAvailable{}
+)
+
+func TestUserEventActor(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label    string
+		input    UserEvent
+		expected User
+	}
+
+	whisper := &WhisperEvent{FromID: 117166826}
+	whisper.Tags.Login = "testaccount_420"
+	whisper.Tags.DisplayName = "TestAccount_420"
+	whisper.Tags.Color = "#FF0000"
+
+	points := &PointsEvent{}
+	points.User.Id = "11148817"
+	points.User.User = "pajlada"
+	points.User.DisplayName = "pajlada"
+
+	anonymousBits, err := parseBitsEvent([]byte(`{"data":{"user_name":"ananonymouscheerer","channel_name":"bontakun","user_id":"407665396","channel_id":"46024993","time":"2017-02-09T13:23:58.168Z","chat_message":"Anon100","bits_used":100,"total_bits_used":0,"context":"cheer","badge_entitlement":null},"version":"1.0","message_type":"bits_event","message_id":"bc6ad0a7-4b8d-5ee4-9d10-0c8a9d2bd3c9","is_anonymous":true}`))
+	c.Assert(err, qt.IsNil)
+
+	testCases := []testCase{
+		{
+			label: "Bits",
+			input: &BitsEvent{UserID: "11148817", UserName: "pajlada"},
+			expected: User{
+				ID:    "11148817",
+				Login: "pajlada",
+			},
+		},
+		{
+			label:    "Anonymous bits",
+			input:    anonymousBits,
+			expected: User{},
+		},
+		{
+			label: "Raid",
+			input: &RaidGo{Raid: Raid{CreatorID: "11148817", SourceID: "11148817", TargetID: "22484632"}},
+			expected: User{
+				ID: "11148817",
+			},
+		},
+		{
+			label: "Points",
+			input: points,
+			expected: User{
+				ID:          "11148817",
+				Login:       "pajlada",
+				DisplayName: "pajlada",
+			},
+		},
+		{
+			label: "Whisper with a numeric ID",
+			input: whisper,
+			expected: User{
+				ID:          "117166826",
+				Login:       "testaccount_420",
+				DisplayName: "TestAccount_420",
+				Color:       "#FF0000",
+			},
+		},
+		{
+			label: "Low trust user message",
+			input: &LowTrustUserEvent{
+				Type: LowTrustUserNewMessage,
+				NewMessage: &LowTrustUserMessage{
+					LowTrustUser: LowTrustUser{
+						Sender: &LowTrustUserSender{
+							UserID:      "40286300",
+							Login:       "randers",
+							DisplayName: "randers",
+							ChatColor:   "#00FF7F",
+						},
+					},
+				},
+			},
+			expected: User{
+				ID:          "40286300",
+				Login:       "randers",
+				DisplayName: "randers",
+				Color:       "#00FF7F",
+			},
+		},
+		{
+			label: "Canceled unban request",
+			input: &UnbanRequestUpdate{
+				UnbanRequest: UnbanRequest{
+					RequesterID:    "40286300",
+					RequesterLogin: "randers",
+					Status:         UnbanRequestStatusCanceled,
+				},
+			},
+			expected: User{
+				ID:    "40286300",
+				Login: "randers",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			c.Assert(testCase.input.Actor(), qt.DeepEquals, testCase.expected)
+		})
+	}
+}
+
+func TestWhisperEventRecipientUser(t *testing.T) {
+	c := qt.New(t)
+
+	whisper := &WhisperEvent{}
+	c.Assert(whisper.RecipientUser(), qt.DeepEquals, User{})
+
+	whisper.Recipient.ID = 11148817
+	whisper.Recipient.Username = "pajlada"
+	c.Assert(whisper.RecipientUser(), qt.DeepEquals, User{ID: "11148817", Login: "pajlada"})
+}
@@ -0,0 +1,72 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch user moderation notification events
+// These describe the chatter's own messages being held by AutoMod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const userModerationNotificationEventTopicPrefix = "user-moderation-notifications."
+
+// Known values of UserModerationNotificationEvent.Status
+const (
+	UserModerationNotificationStatusPending = "PENDING"
+	UserModerationNotificationStatusAllowed = "ALLOWED"
+	UserModerationNotificationStatusDenied  = "DENIED"
+	UserModerationNotificationStatusExpired = "EXPIRED"
+)
+
+// UserModerationNotificationEvent describes the state of the authenticated user's message being held by AutoMod, coming from Twitch's PubSub servers
+type UserModerationNotificationEvent struct {
+	// Type is the kind of notification, e.g. "automod_caught_message"
+	Type string `json:"-"`
+
+	// MessageID is the ID of the message that was caught by AutoMod
+	MessageID string `json:"message_id"`
+
+	// Status is one of the UserModerationNotificationStatus* constants
+	Status string `json:"status"`
+
+	RawEvent
+}
+
+type outerUserModerationNotificationEvent struct {
+	Type string                          `json:"type"`
+	Data UserModerationNotificationEvent `json:"data"`
+}
+
+func parseUserModerationNotificationEvent(bytes []byte) (*UserModerationNotificationEvent, error) {
+	data := &outerUserModerationNotificationEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Data.Type = data.Type
+	data.Data.RawEvent = newRawEvent(bytes, data)
+
+	return &data.Data, nil
+}
+
+func parseChannelIDFromUserModerationNotificationTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 {
+		return "", errors.New("unable to parse channel ID from user moderation notification topic")
+	}
+
+	return parts[2], nil
+}
+
+func isUserModerationNotificationEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, userModerationNotificationEventTopicPrefix)
+}
+
+// UserModerationNotificationEventTopic returns a properly formatted user moderation notification event topic string with the given user and channel ID arguments
+func UserModerationNotificationEventTopic(userID, channelID string) string {
+	const f = `user-moderation-notifications.%s.%s`
+	return fmt.Sprintf(f, userID, channelID)
+}
@@ -0,0 +1,115 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseUserModerationNotificationEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         *UserModerationNotificationEvent
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	testCases := []testCase{
+		{
+			label:      "Pending",
+			input:      `{"type":"MESSAGE","data":{"topic":"user-moderation-notifications.133077169.11148817","message":"{\"type\":\"automod_caught_message\",\"data\":{\"message_id\":\"d2c2c8f0-5a4d-4d8a-9b8c-1b0b0b0b0b0b\",\"status\":\"PENDING\"}}"}}`,
+			isValidMsg: true,
+			expected: &UserModerationNotificationEvent{
+				Type:      "automod_caught_message",
+				MessageID: "d2c2c8f0-5a4d-4d8a-9b8c-1b0b0b0b0b0b",
+				Status:    UserModerationNotificationStatusPending,
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:      "Denied",
+			input:      `{"type":"MESSAGE","data":{"topic":"user-moderation-notifications.133077169.11148817","message":"{\"type\":\"automod_caught_message\",\"data\":{\"message_id\":\"d2c2c8f0-5a4d-4d8a-9b8c-1b0b0b0b0b0b\",\"status\":\"DENIED\"}}"}}`,
+			isValidMsg: true,
+			expected: &UserModerationNotificationEvent{
+				Type:      "automod_caught_message",
+				MessageID: "d2c2c8f0-5a4d-4d8a-9b8c-1b0b0b0b0b0b",
+				Status:    UserModerationNotificationStatusDenied,
+			},
+			expectedErr:      nil,
+			expectedOuterErr: nil,
+		},
+		{
+			label:            "Invalid message JSON",
+			input:            `{"type":"MESSAGE","data":{"topic":"user-moderation-notifications.133077169.11148817","message":"{forsen}"}}`,
+			isValidMsg:       true,
+			expected:         nil,
+			expectedErr:      errors.New("invalid character 'f' looking for beginning of object key string"),
+			expectedOuterErr: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(isUserModerationNotificationEventTopic(outerMessage.Data.Topic), qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseUserModerationNotificationEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseUserModerationNotificationTopicChannelID(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label             string
+		inputTopic        string
+		expectedChannelID string
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			label:             "Standard",
+			inputTopic:        UserModerationNotificationEventTopic("123", "456"),
+			expectedChannelID: "456",
+			expectedErr:       nil,
+		},
+		{
+			label:             "Malformed",
+			inputTopic:        "user-moderation-notifications.123",
+			expectedChannelID: "",
+			expectedErr:       errors.New("unable to parse channel ID from user moderation notification topic"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			actualChannelID, err := parseChannelIDFromUserModerationNotificationTopic(testCase.inputTopic)
+			if testCase.expectedErr == nil {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+			}
+			c.Assert(actualChannelID, qt.Equals, testCase.expectedChannelID)
+		})
+	}
+}
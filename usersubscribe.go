@@ -0,0 +1,50 @@
+package twitchpubsub
+
+// Helper functions and structures for twitch user subscribe events
+// These describe the authenticated user's own subscriptions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const userSubscribeEventTopicPrefix = "user-subscribe-events-v1."
+
+// UserSubscribeEvent describes the authenticated user subscribing to, or being gifted a subscription to, a channel
+// The payload has the same shape as a SubscribeEvent on the channel subscribe topic
+type UserSubscribeEvent struct {
+	SubscribeEvent
+}
+
+func parseUserSubscribeEvent(bytes []byte) (*UserSubscribeEvent, error) {
+	data := &UserSubscribeEvent{}
+	err := json.Unmarshal(bytes, data)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RawEvent = newRawEvent(bytes, data)
+
+	return data, nil
+}
+
+func parseUserIDFromUserSubscribeTopic(topic string) (string, error) {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 2 {
+		return "", errors.New("unable to parse user ID from user subscribe topic")
+	}
+
+	return parts[1], nil
+}
+
+func isUserSubscribeEventTopic(topic string) bool {
+	return strings.HasPrefix(topic, userSubscribeEventTopicPrefix)
+}
+
+// UserSubscribeEventTopic returns a properly formatted user subscribe event topic string with the given user ID argument
+func UserSubscribeEventTopic(userID string) string {
+	const f = `user-subscribe-events-v1.%s`
+	return fmt.Sprintf(f, userID)
+}
@@ -0,0 +1,49 @@
+package twitchpubsub
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseUserSubscribeEvent(t *testing.T) {
+	c := qt.New(t)
+
+	input := `{"type":"MESSAGE","data":{"topic":"user-subscribe-events-v1.40286300","message":"{\"benefit_end_month\":0,\"user_name\":\"randers\",\"display_name\":\"randers\",\"channel_name\":\"pajlada\",\"user_id\":\"40286300\",\"channel_id\":\"11148817\",\"time\":\"2023-06-11T10:44:06.975336457Z\",\"sub_message\":{\"message\":\"\",\"emotes\":null},\"sub_plan\":\"Prime\",\"sub_plan_name\":\"look at those shitty emotes, rip $5 LUL\",\"months\":0,\"cumulative_months\":54,\"context\":\"resub\",\"is_gift\":false,\"multi_month_duration\":0}"}}`
+
+	outerMessage, err := parseOuterMessage([]byte(input))
+	c.Assert(err, qt.IsNil)
+	c.Assert(isUserSubscribeEventTopic(outerMessage.Data.Topic), qt.IsTrue)
+
+	actual, err := parseUserSubscribeEvent([]byte(outerMessage.Data.Message))
+	c.Assert(err, qt.IsNil)
+	c.Assert(actual, deepEqualsIgnoringRaw, &UserSubscribeEvent{
+		SubscribeEvent: SubscribeEvent{
+			ChannelID:        "11148817",
+			ChannelName:      "pajlada",
+			UserID:           "40286300",
+			UserName:         "randers",
+			DisplayName:      "randers",
+			Time:             time.Date(2023, time.June, 11, 10, 44, 6, 975336457, time.UTC),
+			SubPlan:          "Prime",
+			SubPlanName:      "look at those shitty emotes, rip $5 LUL",
+			CumulativeMonths: 54,
+			Context:          SubscribeContextResub,
+		},
+	})
+
+	_, err = parseUserSubscribeEvent([]byte(`{forsen}`))
+	c.Assert(err, qt.ErrorMatches, "invalid character 'f' looking for beginning of object key string")
+}
+
+func TestParseUserSubscribeTopicUserID(t *testing.T) {
+	c := qt.New(t)
+
+	userID, err := parseUserIDFromUserSubscribeTopic(UserSubscribeEventTopic("123"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, "123")
+
+	_, err = parseUserIDFromUserSubscribeTopic("user-subscribe-events-v1")
+	c.Assert(err, qt.ErrorMatches, "unable to parse user ID from user subscribe topic")
+}
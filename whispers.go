@@ -11,8 +11,18 @@ import (
 
 const whisperEventTopicPrefix = "whispers."
 
+// Known values of the whisper message type
+const (
+	WhisperTypeReceived = "whisper_received"
+	WhisperTypeSent     = "whisper_sent"
+	WhisperTypeThread   = "thread"
+)
+
 // WhisperEvent describes an incoming whisper coming from Twitch's PubSub servers
 type WhisperEvent struct {
+	// Type is either WhisperTypeReceived or WhisperTypeSent
+	Type string `json:"-"`
+
 	MessageID string `json:"message_id"`
 	ID        int    `json:"id"`
 	ThreadID  string `json:"thread_id"`
@@ -36,22 +46,92 @@ type WhisperEvent struct {
 		Color       string `json:"color"`
 	} `json:"recipient"`
 	Nonce string `json:"nonce"`
+
+	RawEvent
+}
+
+// WhisperSentEvent describes a whisper sent by the user the topic is for, coming from Twitch's PubSub servers
+type WhisperSentEvent struct {
+	WhisperEvent
+}
+
+// WhisperSpamInfo describes how likely a whisper thread is to be spam
+type WhisperSpamInfo struct {
+	Likelihood        string `json:"likelihood"`
+	LastMarkedNotSpam int    `json:"last_marked_not_spam"`
+}
+
+// WhisperThreadEvent describes a change to the state of a whisper thread, e.g. it being archived, muted or read
+type WhisperThreadEvent struct {
+	ID       string          `json:"id"`
+	LastRead int             `json:"last_read"`
+	Archived bool            `json:"archived"`
+	Muted    bool            `json:"muted"`
+	SpamInfo WhisperSpamInfo `json:"spam_info"`
+
+	RawEvent
 }
 
 type outerWhisperEvent struct {
-	Type       string       `json:"type"`
-	Data       string       `json:"data"`
-	DataObject WhisperEvent `json:"data_object"`
+	Type       string          `json:"type"`
+	Data       string          `json:"data"`
+	DataObject json.RawMessage `json:"data_object"`
 }
 
-func parseWhisperEvent(bytes []byte) (*WhisperEvent, error) {
+// unmarshalData decodes data_object into v, falling back to the data string which holds the same object
+// raw is filled in with the message and the fields of the decoded object that v did not recognize
+func (e *outerWhisperEvent) unmarshalData(bytes []byte, v interface{}, raw *RawEvent) error {
+	path, data := "data_object", []byte(e.DataObject)
+	if len(e.DataObject) == 0 || string(e.DataObject) == "null" {
+		path, data = "data", []byte(e.Data)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	*raw = newRawEvent(bytes, e)
+	raw.addUnknownFields(path, data, v)
+
+	return nil
+}
+
+// parseWhisperEvent parses any message sent on the whispers topic
+// The returned value is one of *WhisperEvent, *WhisperSentEvent or *WhisperThreadEvent
+// Returns nil without an error for message types we don't handle
+func parseWhisperEvent(bytes []byte) (interface{}, error) {
 	data := &outerWhisperEvent{}
 	err := json.Unmarshal(bytes, data)
 	if err != nil {
 		return nil, err
 	}
 
-	return &data.DataObject, nil
+	switch data.Type {
+	case WhisperTypeReceived:
+		event := &WhisperEvent{}
+		if err := data.unmarshalData(bytes, event, &event.RawEvent); err != nil {
+			return nil, err
+		}
+		event.Type = data.Type
+		return event, nil
+
+	case WhisperTypeSent:
+		event := &WhisperSentEvent{}
+		if err := data.unmarshalData(bytes, &event.WhisperEvent, &event.RawEvent); err != nil {
+			return nil, err
+		}
+		event.Type = data.Type
+		return event, nil
+
+	case WhisperTypeThread:
+		event := &WhisperThreadEvent{}
+		if err := data.unmarshalData(bytes, event, &event.RawEvent); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+
+	return nil, nil
 }
 
 func parseUserIDFromWhisperTopic(topic string) (string, error) {
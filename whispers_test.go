@@ -0,0 +1,125 @@
+package twitchpubsub
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseWhisperEvent(t *testing.T) {
+	c := qt.New(t)
+
+	type testCase struct {
+		label            string
+		input            string
+		isValidMsg       bool
+		expected         interface{}
+		expectedErr      error
+		expectedOuterErr error
+	}
+
+	received := &WhisperEvent{
+		Type:      WhisperTypeReceived,
+		MessageID: "6c9d5a4e-4dbc-4e3b-a7ce-5e2d7ec2f5a1",
+		ID:        42,
+		ThreadID:  "11148817_117166826",
+		Body:      "forsen hello",
+		SentTs:    1603051200,
+		FromID:    117166826,
+		Nonce:     "abc",
+	}
+	received.Tags.Login = "testaccount_420"
+	received.Tags.DisplayName = "TestAccount_420"
+	received.Tags.Color = "#FF0000"
+	received.Tags.Emotes = []interface{}{}
+	received.Tags.Badges = []struct {
+		ID      string `json:"id"`
+		Version string `json:"version"`
+	}{}
+	received.Recipient.ID = 11148817
+	received.Recipient.Username = "pajlada"
+	received.Recipient.DisplayName = "pajlada"
+
+	sent := &WhisperSentEvent{WhisperEvent: *received}
+	sent.Type = WhisperTypeSent
+
+	testCases := []testCase{
+		{
+			label:      "Received",
+			input:      `{"type":"MESSAGE","data":{"topic":"whispers.11148817","message":"{\"type\":\"whisper_received\",\"data\":\"{}\",\"data_object\":{\"message_id\":\"6c9d5a4e-4dbc-4e3b-a7ce-5e2d7ec2f5a1\",\"id\":42,\"thread_id\":\"11148817_117166826\",\"body\":\"forsen hello\",\"sent_ts\":1603051200,\"from_id\":117166826,\"tags\":{\"login\":\"testaccount_420\",\"display_name\":\"TestAccount_420\",\"color\":\"#FF0000\",\"emotes\":[],\"badges\":[]},\"recipient\":{\"id\":11148817,\"username\":\"pajlada\",\"display_name\":\"pajlada\",\"color\":\"\"},\"nonce\":\"abc\"}}"}}`,
+			isValidMsg: true,
+			expected:   received,
+		},
+		{
+			label:      "Sent, only data string",
+			input:      `{"type":"MESSAGE","data":{"topic":"whispers.117166826","message":"{\"type\":\"whisper_sent\",\"data\":\"{\\\"message_id\\\":\\\"6c9d5a4e-4dbc-4e3b-a7ce-5e2d7ec2f5a1\\\",\\\"id\\\":42,\\\"thread_id\\\":\\\"11148817_117166826\\\",\\\"body\\\":\\\"forsen hello\\\",\\\"sent_ts\\\":1603051200,\\\"from_id\\\":117166826,\\\"tags\\\":{\\\"login\\\":\\\"testaccount_420\\\",\\\"display_name\\\":\\\"TestAccount_420\\\",\\\"color\\\":\\\"#FF0000\\\",\\\"emotes\\\":[],\\\"badges\\\":[]},\\\"recipient\\\":{\\\"id\\\":11148817,\\\"username\\\":\\\"pajlada\\\",\\\"display_name\\\":\\\"pajlada\\\",\\\"color\\\":\\\"\\\"},\\\"nonce\\\":\\\"abc\\\"}\"}"}}`,
+			isValidMsg: true,
+			expected:   sent,
+		},
+		{
+			label:      "Thread",
+			input:      `{"type":"MESSAGE","data":{"topic":"whispers.11148817","message":"{\"type\":\"thread\",\"data\":\"{\\\"id\\\":\\\"11148817_117166826\\\",\\\"last_read\\\":42,\\\"archived\\\":true,\\\"muted\\\":false,\\\"spam_info\\\":{\\\"likelihood\\\":\\\"low\\\",\\\"last_marked_not_spam\\\":0}}\",\"data_object\":{\"id\":\"11148817_117166826\",\"last_read\":42,\"archived\":true,\"muted\":false,\"spam_info\":{\"likelihood\":\"low\",\"last_marked_not_spam\":0}}}"}}`,
+			isValidMsg: true,
+			expected: &WhisperThreadEvent{
+				ID:       "11148817_117166826",
+				LastRead: 42,
+				Archived: true,
+				Muted:    false,
+				SpamInfo: WhisperSpamInfo{
+					Likelihood: "low",
+				},
+			},
+		},
+		{
+			label:       "Unhandled type",
+			input:       `{"type":"MESSAGE","data":{"topic":"whispers.11148817","message":"{\"type\":\"whisper_forsen\",\"data\":\"{}\"}"}}`,
+			isValidMsg:  true,
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			label:       "Invalid message JSON",
+			input:       `{"type":"MESSAGE","data":{"topic":"whispers.11148817","message":"{forsen}"}}`,
+			isValidMsg:  true,
+			expected:    nil,
+			expectedErr: errors.New("invalid character 'f' looking for beginning of object key string"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		c.Run(testCase.label, func(c *qt.C) {
+			outerMessage, err := parseOuterMessage([]byte(testCase.input))
+			c.Assert(err, qt.Equals, testCase.expectedOuterErr)
+			c.Assert(getMessageType(outerMessage.Data.Topic) == messageTypeWhisperEvent, qt.Equals, testCase.isValidMsg)
+
+			if testCase.isValidMsg {
+				innerMessageBytes := []byte(outerMessage.Data.Message)
+				actual, err := parseWhisperEvent(innerMessageBytes)
+
+				if testCase.expectedErr == nil {
+					c.Assert(err, qt.IsNil)
+				} else {
+					c.Assert(err, qt.ErrorMatches, testCase.expectedErr.Error())
+				}
+
+				if testCase.expected == nil {
+					c.Assert(actual, qt.IsNil)
+				} else {
+					c.Assert(actual, deepEqualsIgnoringRaw, testCase.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestParseWhisperTopicUserID(t *testing.T) {
+	c := qt.New(t)
+
+	userID, err := parseUserIDFromWhisperTopic(WhisperEventTopic("11148817"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(userID, qt.Equals, "11148817")
+
+	_, err = parseUserIDFromWhisperTopic("whispers")
+	c.Assert(err, qt.ErrorMatches, "unable to parse channel ID from whisper topic")
+}